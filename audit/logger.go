@@ -0,0 +1,317 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// 기록이 변조되었거나 연결이 끊긴 경우
+	ErrChainBroken = errors.New("감사 기록의 해시 연결이 끊어졌습니다")
+)
+
+const (
+	// 현재 기록 중인 파일 이름
+	currentFileName = "audit.jsonl"
+	// 교체된 파일 이름의 접두사, 뒤에 교체 시각이 붙음
+	rotatedFilePrefix = "audit-"
+	rotatedFileSuffix = ".jsonl"
+	// 교체된 파일 이름의 시각 형식, 이름순 정렬이 시간순이 되도록 함
+	rotatedTimeFormat = "20060102T150405.000000000"
+)
+
+type LoggerParam struct {
+	// 기록 파일을 저장할 폴더
+	Dir string
+	// 파일이 이 크기(byte)를 넘으면 교체, 0 이하이면 크기로 교체하지 않음
+	MaxBytes int64
+	// 파일의 첫 기록 후 이 시간이 지나면 교체, 0 이하이면 시간으로 교체하지 않음
+	MaxAge time.Duration
+}
+
+/*
+추가만 가능한 JSON Lines 감사 기록기
+모든 기록은 이전 기록의 해시를 포함하여, 파일을 교체해도 하나의 해시 연결을 이룸
+*/
+type Logger struct {
+	param     LoggerParam
+	mutex     sync.Mutex
+	file      *os.File
+	size      int64
+	startedAt time.Time
+	lastHash  string
+}
+
+/*
+폴더의 감사 기록 파일을 열어 Logger 생성
+기존 기록이 있으면 마지막 기록의 해시부터 이어서 기록
+*/
+func NewLogger(param LoggerParam) (*Logger, error) {
+	if err := os.MkdirAll(param.Dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	l := &Logger{param: param}
+	currentPath := filepath.Join(param.Dir, currentFileName)
+	first, last, err := readFirstLastRecord(currentPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if first != nil {
+		l.startedAt, _ = time.Parse(time.RFC3339Nano, first.Time)
+		l.lastHash = last.Hash
+	} else {
+		// 현재 파일이 비어있으면 마지막으로 교체된 파일에서 연결
+		rotatedPaths, err := listRotatedFiles(param.Dir)
+		if err != nil {
+			return nil, err
+		}
+		if len(rotatedPaths) > 0 {
+			_, last, err := readFirstLastRecord(rotatedPaths[len(rotatedPaths)-1])
+			if err != nil {
+				return nil, err
+			}
+			if last != nil {
+				l.lastHash = last.Hash
+			}
+		}
+	}
+
+	if err := l.openCurrentFile(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+/*
+기록 추가
+시각, 이전 해시, 해시는 Logger가 설정
+*/
+func (l *Logger) Log(record Record) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.file == nil {
+		return os.ErrClosed
+	}
+
+	now := time.Now()
+	if l.shouldRotate(now) {
+		if err := l.rotate(now); err != nil {
+			return err
+		}
+	}
+
+	record.Time = now.UTC().Format(time.RFC3339Nano)
+	record.PrevHash = l.lastHash
+	hash, err := record.computeHash()
+	if err != nil {
+		return err
+	}
+	record.Hash = hash
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	if _, err := l.file.Write(data); err != nil {
+		return err
+	}
+
+	if l.size == 0 {
+		l.startedAt = now
+	}
+	l.size += int64(len(data))
+	l.lastHash = hash
+	return nil
+}
+
+/*
+현재 기록 중인 파일의 크기(byte) 반환
+파일을 교체하면 새 파일의 크기부터 다시 계산
+*/
+func (l *Logger) Size() int64 {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.size
+}
+
+/*
+기록 파일을 디스크에 쓴 후 닫기
+*/
+func (l *Logger) Close() error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.file == nil {
+		return nil
+	}
+	err := errors.Join(l.file.Sync(), l.file.Close())
+	l.file = nil
+	return err
+}
+
+/*
+현재 파일을 교체해야 하는지 여부 반환
+*/
+func (l *Logger) shouldRotate(now time.Time) bool {
+	if l.size == 0 {
+		return false
+	}
+	return (l.param.MaxBytes > 0 && l.size >= l.param.MaxBytes) ||
+		(l.param.MaxAge > 0 && now.Sub(l.startedAt) >= l.param.MaxAge)
+}
+
+/*
+현재 파일을 교체 시각이 붙은 이름으로 바꾸고 새 파일 열기
+*/
+func (l *Logger) rotate(now time.Time) error {
+	if err := l.file.Close(); err != nil {
+		return err
+	}
+	l.file = nil
+
+	rotatedPath := filepath.Join(l.param.Dir, rotatedFilePrefix+now.UTC().Format(rotatedTimeFormat)+rotatedFileSuffix)
+	if err := os.Rename(filepath.Join(l.param.Dir, currentFileName), rotatedPath); err != nil {
+		return err
+	}
+	return l.openCurrentFile()
+}
+
+/*
+현재 파일을 추가 모드로 열기
+*/
+func (l *Logger) openCurrentFile() error {
+	file, err := os.OpenFile(filepath.Join(l.param.Dir, currentFileName), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	l.file = file
+	l.size = info.Size()
+	return nil
+}
+
+/*
+폴더의 모든 감사 기록 파일을 오래된 순서로 읽으며 해시 연결 검증
+  - @return {int} 검증한 기록 개수
+  - @return {error} 변조된 기록이 있으면 파일 이름과 줄 번호를 포함한 ErrChainBroken
+*/
+func Verify(dir string) (int, error) {
+	paths, err := listRotatedFiles(dir)
+	if err != nil {
+		return 0, err
+	}
+	currentPath := filepath.Join(dir, currentFileName)
+	if _, err := os.Stat(currentPath); err == nil {
+		paths = append(paths, currentPath)
+	}
+
+	count := 0
+	lastHash := ""
+	for _, path := range paths {
+		err := forEachRecordLine(path, func(lineNumber int, line []byte) error {
+			var record Record
+			if err := json.Unmarshal(line, &record); err != nil {
+				return fmt.Errorf("%s:%d: %w: %v", filepath.Base(path), lineNumber, ErrChainBroken, err)
+			}
+			if record.PrevHash != lastHash {
+				return fmt.Errorf("%s:%d: %w: 이전 기록의 해시와 다릅니다", filepath.Base(path), lineNumber, ErrChainBroken)
+			}
+			hash, err := record.computeHash()
+			if err != nil {
+				return err
+			}
+			if record.Hash != hash {
+				return fmt.Errorf("%s:%d: %w: 기록의 해시가 내용과 다릅니다", filepath.Base(path), lineNumber, ErrChainBroken)
+			}
+
+			lastHash = record.Hash
+			count++
+			return nil
+		})
+		if err != nil {
+			return count, err
+		}
+	}
+	return count, nil
+}
+
+/*
+교체된 기록 파일 경로를 오래된 순서로 반환
+*/
+func listRotatedFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := []string{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() && strings.HasPrefix(name, rotatedFilePrefix) && strings.HasSuffix(name, rotatedFileSuffix) {
+			paths = append(paths, filepath.Join(dir, name))
+		}
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+/*
+기록 파일의 첫 기록과 마지막 기록 반환
+파일이 비어있으면 nil
+*/
+func readFirstLastRecord(path string) (*Record, *Record, error) {
+	var first, last *Record
+	err := forEachRecordLine(path, func(lineNumber int, line []byte) error {
+		var record Record
+		if err := json.Unmarshal(line, &record); err != nil {
+			return fmt.Errorf("%s:%d: %w", filepath.Base(path), lineNumber, err)
+		}
+		if first == nil {
+			first = &record
+		}
+		last = &record
+		return nil
+	})
+	return first, last, err
+}
+
+/*
+기록 파일의 비어있지 않은 줄마다 함수 호출
+*/
+func forEachRecordLine(path string, fn func(lineNumber int, line []byte) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		if err := fn(lineNumber, scanner.Bytes()); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
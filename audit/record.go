@@ -0,0 +1,96 @@
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+const (
+	// 서버 요청
+	RECORD_TYPE_REQUEST = "request"
+	// 권한 변경
+	RECORD_TYPE_ACL = "acl"
+	// 잠금 변경
+	RECORD_TYPE_LOCK = "lock"
+)
+
+const (
+	DECISION_ALLOW = "allow"
+	DECISION_DENY  = "deny"
+)
+
+/*
+감사 기록 한 줄
+Hash는 Hash를 비운 기록의 JSON을 SHA-256으로 해시한 값이며, PrevHash로 이전 기록과 연결됨
+*/
+type Record struct {
+	// 기록 시각 (RFC3339Nano)
+	Time string `json:"time"`
+	// RECORD_TYPE_REQUEST, RECORD_TYPE_ACL, RECORD_TYPE_LOCK
+	Type string `json:"type"`
+	// 요청 ID (`X-Request-ID`), 요청과 요청 중 일어난 변경을 연결
+	RequestId string `json:"requestId,omitempty"`
+	User      string `json:"user"`
+	Group     string `json:"group"`
+	// 요청 메소드 또는 변경 작업 이름 (예시: "GET", "AddUserPermission")
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	// 요청을 허용하거나 거부한 권한 확인, 거부한 확인이 있으면 그 확인, 없으면 마지막 확인
+	Permission string `json:"permission"`
+	// DECISION_ALLOW, DECISION_DENY, 권한을 확인하지 않았으면 빈 문자열
+	Decision string `json:"decision"`
+	Reason   string `json:"reason"`
+	// 응답 코드, 요청이 아니면 0
+	Status int `json:"status"`
+	// 요청 중 확인한 모든 권한
+	Checks []Check `json:"checks,omitempty"`
+	// 변경 내용 (예시: 권한을 변경한 유저, 잠금 토큰)
+	Detail   map[string]string `json:"detail,omitempty"`
+	PrevHash string            `json:"prevHash"`
+	Hash     string            `json:"hash"`
+}
+
+/*
+권한 확인 한 번의 결과
+*/
+type Check struct {
+	Path       string `json:"path"`
+	Permission string `json:"permission"`
+	Decision   string `json:"decision"`
+	Reason     string `json:"reason"`
+}
+
+/*
+기록의 해시 계산
+*/
+func (r Record) computeHash() (string, error) {
+	r.Hash = ""
+	data, err := json.Marshal(r)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+/*
+확인한 권한 목록에서 요청을 허용하거나 거부한 확인을 기록에 설정
+*/
+func (r *Record) SetChecks(checks []Check) {
+	r.Checks = checks
+	if len(checks) == 0 {
+		return
+	}
+
+	decisive := checks[len(checks)-1]
+	for _, check := range checks {
+		if check.Decision == DECISION_DENY {
+			decisive = check
+			break
+		}
+	}
+	r.Permission = decisive.Permission
+	r.Decision = decisive.Decision
+	r.Reason = decisive.Reason
+}
@@ -0,0 +1,36 @@
+package class
+
+import "errors"
+
+var (
+	// 경로가 '/'로 시작하지 않거나 빈 이름이 포함된 경우
+	ErrInvalidPath = errors.New("올바르지 않은 경로입니다")
+	// 경로에 리소스가 존재하지 않는 경우
+	ErrNotFound = errors.New("리소스가 존재하지 않습니다")
+	// 경로에 이미 리소스가 존재하는 경우
+	ErrAlreadyExists = errors.New("이미 리소스가 존재합니다")
+	// 상위 폴더를 생성하지 않는 경우 상위 폴더가 없을 때
+	ErrParentNotFound = errors.New("상위 폴더가 존재하지 않습니다")
+	// 파일 아래에 리소스를 생성하려는 경우
+	ErrNotDirectory = errors.New("상위 리소스가 폴더가 아닙니다")
+	// 폴더의 내용을 변경하려는 경우
+	ErrNotFile = errors.New("리소스가 파일이 아닙니다")
+	// 잠긴 리소스를 올바른 잠금 토큰 없이 변경하려는 경우
+	ErrLocked = errors.New("리소스가 잠겨있습니다")
+	// 보관되지 않은 버전을 조회하거나 복원하려는 경우
+	ErrVersionNotFound = errors.New("버전이 존재하지 않습니다")
+	// 휴지통에 없는 항목을 복원하려는 경우
+	ErrTrashItemNotFound = errors.New("휴지통 항목이 존재하지 않습니다")
+	// 없는 스냅샷을 조회하거나 복원하려는 경우
+	ErrSnapshotNotFound = errors.New("스냅샷이 존재하지 않습니다")
+	// 작업에 필요한 권한이 없는 리소스가 포함된 경우
+	ErrPermissionDenied = errors.New("권한이 없는 리소스가 포함되어 있습니다")
+	// 저장 용량 제한을 넘는 경우
+	ErrQuotaExceeded = errors.New("저장 용량 제한을 넘었습니다")
+	// 불러오거나 가져올 데이터의 형식이 올바르지 않은 경우
+	ErrInvalidData = errors.New("데이터 형식이 올바르지 않습니다")
+	// 트리가 참조하는 파일 내용이 저장소에 없는 경우
+	ErrContentMissing = errors.New("저장소에 없는 파일 내용이 있습니다")
+	// View 안에서 트리를 변경하려는 경우
+	ErrReadOnlyView = errors.New("읽기 잠금을 잡은 상태에서 트리를 변경할 수 없습니다")
+)
@@ -0,0 +1,102 @@
+package class
+
+/*
+유저, 그룹별로 생성한 리소스의 사용량
+트리에 붙어있는 리소스만 집계하며, 리소스를 붙이고 떼거나 파일 크기가 바뀔 때 변경량만 반영
+*/
+type ownerUsageIndex struct {
+	users  map[string]QuotaUsage
+	groups map[string]QuotaUsage
+}
+
+/*
+리소스와 하위 리소스를 집계한 사용량 생성
+*/
+func newOwnerUsageIndex(root *ResourceObject) *ownerUsageIndex {
+	index := &ownerUsageIndex{
+		users:  map[string]QuotaUsage{},
+		groups: map[string]QuotaUsage{},
+	}
+	index.addTree(root)
+	return index
+}
+
+/*
+리소스와 하위 리소스의 사용량을 더함
+*/
+func (i *ownerUsageIndex) addTree(resource *ResourceObject) {
+	i.add(resource, 1)
+	for _, child := range resource.childrenMap {
+		i.addTree(child)
+	}
+}
+
+/*
+리소스와 하위 리소스의 사용량을 뺌
+*/
+func (i *ownerUsageIndex) removeTree(resource *ResourceObject) {
+	i.add(resource, -1)
+	for _, child := range resource.childrenMap {
+		i.removeTree(child)
+	}
+}
+
+/*
+리소스 하나의 사용량을 더하거나 뺌
+  - @param {int64} sign 더하면 1, 빼면 -1
+*/
+func (i *ownerUsageIndex) add(resource *ResourceObject, sign int64) {
+	usage := QuotaUsage{Nodes: sign}
+	if resource.IsFile() {
+		usage.Bytes = sign * resource.size
+	}
+	addOwnerUsage(i.users, resource.creator, usage)
+	addOwnerUsage(i.groups, resource.creatorGroup, usage)
+}
+
+/*
+파일 크기가 바뀐 후 사용량 갱신
+  - @param {int64} delta 크기 변경량(byte)
+*/
+func (i *ownerUsageIndex) addBytes(resource *ResourceObject, delta int64) {
+	addOwnerUsage(i.users, resource.creator, QuotaUsage{Bytes: delta})
+	addOwnerUsage(i.groups, resource.creatorGroup, QuotaUsage{Bytes: delta})
+}
+
+/*
+유저 또는 그룹의 사용량 반환
+  - @param {bool} isGroup name이 그룹 이름인지 여부
+*/
+func (i *ownerUsageIndex) get(name string, isGroup bool) QuotaUsage {
+	if isGroup {
+		return i.groups[name]
+	}
+	return i.users[name]
+}
+
+func addOwnerUsage(usageMap map[string]QuotaUsage, name string, delta QuotaUsage) {
+	usage := usageMap[name]
+	usage.Bytes += delta.Bytes
+	usage.Nodes += delta.Nodes
+	if usage == (QuotaUsage{}) {
+		delete(usageMap, name)
+		return
+	}
+	usageMap[name] = usage
+}
+
+/*
+트리에 붙인 리소스와 하위 리소스를 권한 역색인과 사용량에 반영
+*/
+func (m *ResourceManager) indexTree(resource *ResourceObject) {
+	m.permissionIndex.addTree(resource)
+	m.ownerUsageIndex.addTree(resource)
+}
+
+/*
+트리에서 뗀 리소스와 하위 리소스를 권한 역색인과 사용량에서 제거
+*/
+func (m *ResourceManager) unindexTree(resource *ResourceObject) {
+	m.permissionIndex.removeTree(resource)
+	m.ownerUsageIndex.removeTree(resource)
+}
@@ -0,0 +1,313 @@
+package class
+
+import (
+	audit "app/audit"
+	"sort"
+	"strconv"
+)
+
+/*
+유저, 그룹 이름에서 권한 항목이 있는 리소스로의 역색인
+트리에 붙어있는 리소스만 색인하며, 리소스 포인터로 색인하므로 이동해도 갱신할 필요 없음
+*/
+type permissionIndex struct {
+	users  map[string](map[*ResourceObject]bool)
+	groups map[string](map[*ResourceObject]bool)
+}
+
+/*
+리소스와 하위 리소스를 색인한 역색인 생성
+*/
+func newPermissionIndex(root *ResourceObject) *permissionIndex {
+	index := &permissionIndex{
+		users:  map[string](map[*ResourceObject]bool){},
+		groups: map[string](map[*ResourceObject]bool){},
+	}
+	index.addTree(root)
+	return index
+}
+
+/*
+리소스와 하위 리소스의 모든 권한 항목을 색인
+*/
+func (i *permissionIndex) addTree(resource *ResourceObject) {
+	for username := range resource.userPermissionMap {
+		i.updateUser(username, resource)
+	}
+	for groupname := range resource.groupPermissionMap {
+		i.updateGroup(groupname, resource)
+	}
+	for _, child := range resource.childrenMap {
+		i.addTree(child)
+	}
+}
+
+/*
+리소스와 하위 리소스를 색인에서 제거
+*/
+func (i *permissionIndex) removeTree(resource *ResourceObject) {
+	for username := range resource.userPermissionMap {
+		removeIndexEntry(i.users, username, resource)
+	}
+	for groupname := range resource.groupPermissionMap {
+		removeIndexEntry(i.groups, groupname, resource)
+	}
+	for _, child := range resource.childrenMap {
+		i.removeTree(child)
+	}
+}
+
+/*
+리소스의 유저 권한 항목이 바뀐 후 색인 갱신
+*/
+func (i *permissionIndex) updateUser(username string, resource *ResourceObject) {
+	if len(resource.userPermissionMap[username]) > 0 {
+		addIndexEntry(i.users, username, resource)
+	} else {
+		removeIndexEntry(i.users, username, resource)
+	}
+}
+
+/*
+리소스의 그룹 권한 항목이 바뀐 후 색인 갱신
+*/
+func (i *permissionIndex) updateGroup(groupname string, resource *ResourceObject) {
+	if len(resource.groupPermissionMap[groupname]) > 0 {
+		addIndexEntry(i.groups, groupname, resource)
+	} else {
+		removeIndexEntry(i.groups, groupname, resource)
+	}
+}
+
+/*
+유저 또는 그룹의 권한 항목이 있는 리소스 목록을 경로순으로 반환
+*/
+func (i *permissionIndex) getResources(username string, groupname string) []*ResourceObject {
+	resourceSet := map[*ResourceObject]bool{}
+	if username != "" {
+		for resource := range i.users[username] {
+			resourceSet[resource] = true
+		}
+	}
+	if groupname != "" {
+		for resource := range i.groups[groupname] {
+			resourceSet[resource] = true
+		}
+	}
+
+	resources := []*ResourceObject{}
+	for resource := range resourceSet {
+		resources = append(resources, resource)
+	}
+	sort.Slice(resources, func(a, b int) bool {
+		return resources[a].GetPath() < resources[b].GetPath()
+	})
+	return resources
+}
+
+func addIndexEntry(index map[string](map[*ResourceObject]bool), name string, resource *ResourceObject) {
+	if index[name] == nil {
+		index[name] = map[*ResourceObject]bool{}
+	}
+	index[name][resource] = true
+}
+
+func removeIndexEntry(index map[string](map[*ResourceObject]bool), name string, resource *ResourceObject) {
+	delete(index[name], resource)
+	if len(index[name]) == 0 {
+		delete(index, name)
+	}
+}
+
+/*
+유저의 모든 권한을 트리 전체에서 삭제
+권한 항목이 있는 리소스만 확인하며, 복원했을 때 권한이 다시 생기지 않도록 휴지통 항목과 스냅샷의 리소스에서도 삭제
+  - @return {[]string} 권한을 삭제한 트리의 리소스 경로 목록
+*/
+func (m *ResourceManager) RevokeAllForUser(username string) []string {
+	m, unlock, err := m.lockTree()
+	if err != nil {
+		return []string{}
+	}
+	defer unlock()
+
+	paths := []string{}
+	for _, resource := range m.permissionIndex.getResources(username, "") {
+		delete(resource.userPermissionMap, username)
+		m.permissionIndex.updateUser(username, resource)
+		m.emitEvent(EVENT_TYPE_ACL_CHANGED, resource, resource.GetPath(), "", "")
+		paths = append(paths, resource.GetPath())
+	}
+	m.forEachDetachedResource(func(resource *ResourceObject) {
+		delete(resource.userPermissionMap, username)
+	})
+	m.logAudit(audit.Record{
+		Type:   audit.RECORD_TYPE_ACL,
+		Method: "RevokeAllForUser",
+		Path:   "/",
+		Detail: map[string]string{
+			"principalType": PRINCIPAL_TYPE_USER,
+			"principal":     username,
+			"resources":     strconv.Itoa(len(paths)),
+		},
+	})
+	return paths
+}
+
+/*
+그룹의 모든 권한을 트리 전체에서 삭제
+권한 항목이 있는 리소스만 확인하며, 복원했을 때 권한이 다시 생기지 않도록 휴지통 항목과 스냅샷의 리소스에서도 삭제
+  - @return {[]string} 권한을 삭제한 트리의 리소스 경로 목록
+*/
+func (m *ResourceManager) RevokeAllForGroup(groupname string) []string {
+	m, unlock, err := m.lockTree()
+	if err != nil {
+		return []string{}
+	}
+	defer unlock()
+
+	paths := []string{}
+	for _, resource := range m.permissionIndex.getResources("", groupname) {
+		delete(resource.groupPermissionMap, groupname)
+		m.permissionIndex.updateGroup(groupname, resource)
+		m.emitEvent(EVENT_TYPE_ACL_CHANGED, resource, resource.GetPath(), "", "")
+		paths = append(paths, resource.GetPath())
+	}
+	m.forEachDetachedResource(func(resource *ResourceObject) {
+		delete(resource.groupPermissionMap, groupname)
+	})
+	m.logAudit(audit.Record{
+		Type:   audit.RECORD_TYPE_ACL,
+		Method: "RevokeAllForGroup",
+		Path:   "/",
+		Detail: map[string]string{
+			"principalType": PRINCIPAL_TYPE_GROUP,
+			"principal":     groupname,
+			"resources":     strconv.Itoa(len(paths)),
+		},
+	})
+	return paths
+}
+
+/*
+유저의 권한 항목을 트리 전체에서 새 이름으로 옮김
+새 이름의 항목이 이미 있는 리소스는 두 항목의 권한을 합침
+휴지통 항목과 스냅샷의 리소스도 함께 옮기며, 반환하는 경로 목록에는 포함하지 않음
+권한 항목만 바꾸며 생성한 유저, 잠금 소유자, 저장 용량 제한은 바꾸지 않음
+  - @return {[]string} 권한을 옮긴 리소스 경로 목록
+*/
+func (m *ResourceManager) RenameUser(username string, newUsername string) []string {
+	m, unlock, err := m.lockTree()
+	if err != nil {
+		return []string{}
+	}
+	defer unlock()
+
+	paths := []string{}
+	if username == newUsername || newUsername == "" {
+		return paths
+	}
+
+	for _, resource := range m.permissionIndex.getResources(username, "") {
+		renameUserPermissions(resource, username, newUsername)
+		m.permissionIndex.updateUser(username, resource)
+		m.permissionIndex.updateUser(newUsername, resource)
+		m.emitEvent(EVENT_TYPE_ACL_CHANGED, resource, resource.GetPath(), "", "")
+		paths = append(paths, resource.GetPath())
+	}
+	m.forEachDetachedResource(func(resource *ResourceObject) {
+		renameUserPermissions(resource, username, newUsername)
+	})
+	m.logAudit(audit.Record{
+		Type:   audit.RECORD_TYPE_ACL,
+		Method: "RenameUser",
+		Path:   "/",
+		Detail: map[string]string{
+			"principalType": PRINCIPAL_TYPE_USER,
+			"principal":     username,
+			"newName":       newUsername,
+			"resources":     strconv.Itoa(len(paths)),
+		},
+	})
+	return paths
+}
+
+/*
+그룹의 권한 항목을 트리 전체에서 새 이름으로 옮김
+새 이름의 항목이 이미 있는 리소스는 두 항목의 권한을 합침
+휴지통 항목과 스냅샷의 리소스도 함께 옮기며, 반환하는 경로 목록에는 포함하지 않음
+권한 항목만 바꾸며 생성한 그룹, 저장 용량 제한은 바꾸지 않음
+  - @return {[]string} 권한을 옮긴 리소스 경로 목록
+*/
+func (m *ResourceManager) RenameGroup(groupname string, newGroupname string) []string {
+	m, unlock, err := m.lockTree()
+	if err != nil {
+		return []string{}
+	}
+	defer unlock()
+
+	paths := []string{}
+	if groupname == newGroupname || newGroupname == "" {
+		return paths
+	}
+
+	for _, resource := range m.permissionIndex.getResources("", groupname) {
+		renameGroupPermissions(resource, groupname, newGroupname)
+		m.permissionIndex.updateGroup(groupname, resource)
+		m.permissionIndex.updateGroup(newGroupname, resource)
+		m.emitEvent(EVENT_TYPE_ACL_CHANGED, resource, resource.GetPath(), "", "")
+		paths = append(paths, resource.GetPath())
+	}
+	m.forEachDetachedResource(func(resource *ResourceObject) {
+		renameGroupPermissions(resource, groupname, newGroupname)
+	})
+	m.logAudit(audit.Record{
+		Type:   audit.RECORD_TYPE_ACL,
+		Method: "RenameGroup",
+		Path:   "/",
+		Detail: map[string]string{
+			"principalType": PRINCIPAL_TYPE_GROUP,
+			"principal":     groupname,
+			"newName":       newGroupname,
+			"resources":     strconv.Itoa(len(paths)),
+		},
+	})
+	return paths
+}
+
+/*
+유저의 권한 항목을 새 이름의 항목으로 합침
+*/
+func renameUserPermissions(resource *ResourceObject, username string, newUsername string) {
+	for _, permission := range resource.userPermissionMap[username] {
+		resource.AddUserPermission(newUsername, permission)
+	}
+	delete(resource.userPermissionMap, username)
+}
+
+/*
+그룹의 권한 항목을 새 이름의 항목으로 합침
+*/
+func renameGroupPermissions(resource *ResourceObject, groupname string, newGroupname string) {
+	for _, permission := range resource.groupPermissionMap[groupname] {
+		resource.AddGroupPermission(newGroupname, permission)
+	}
+	delete(resource.groupPermissionMap, groupname)
+}
+
+/*
+휴지통 항목과 스냅샷의 모든 리소스에 fn 실행
+트리에 붙어있지 않아 색인하지 않는 리소스이므로 모두 확인
+*/
+func (m *ResourceManager) forEachDetachedResource(fn func(resource *ResourceObject)) {
+	visit := func(resource *ResourceObject, depth int) error {
+		fn(resource)
+		return nil
+	}
+	for _, item := range m.trashItems {
+		walkResourceObject(item.resource, 0, WalkParam{}, visit)
+	}
+	for _, snapshot := range m.snapshots {
+		walkResourceObject(snapshot.root, 0, WalkParam{}, visit)
+	}
+}
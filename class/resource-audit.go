@@ -0,0 +1,97 @@
+package class
+
+import (
+	audit "app/audit"
+	util "app/util"
+	"strconv"
+	"time"
+)
+
+/*
+권한, 잠금 변경을 기록할 감사 기록기 설정
+nil이면 기록하지 않음
+*/
+func (m *ResourceManager) SetAuditLogger(auditLogger *audit.Logger) {
+	m, unlock, err := m.lockTree()
+	if err != nil {
+		return
+	}
+	defer unlock()
+	m.auditLogger = auditLogger
+}
+
+/*
+감사 기록기 반환
+*/
+func (m *ResourceManager) GetAuditLogger() *audit.Logger {
+	m, unlock := m.rlockTree()
+	defer unlock()
+	return m.auditLogger
+}
+
+/*
+감사 기록 추가
+감사 기록기가 없으면 무시
+*/
+func (m *ResourceManager) logAudit(record audit.Record) {
+	if m.auditLogger == nil {
+		return
+	}
+	record.RequestId = util.GetRequestId(m.GetContext())
+	m.auditLogger.Log(record)
+}
+
+/*
+권한 변경 기록
+  - @param {string} method 변경 작업 이름
+  - @param {string} modifier 권한을 변경한 유저, 알 수 없으면 빈 문자열
+*/
+func (m *ResourceManager) logAclChange(method string, path string, modifier string, principalType string, principal string, permission string) {
+	m.logAudit(audit.Record{
+		Type:   audit.RECORD_TYPE_ACL,
+		User:   modifier,
+		Method: method,
+		Path:   path,
+		Detail: map[string]string{
+			"principalType": principalType,
+			"principal":     principal,
+			"permission":    permission,
+		},
+	})
+}
+
+/*
+잠금 변경 기록
+*/
+func (m *ResourceManager) logLockChange(method string, lock ResourceLock) {
+	expiresAt := ""
+	if !lock.expiresAt.IsZero() {
+		expiresAt = lock.expiresAt.UTC().Format(time.RFC3339)
+	}
+
+	m.logAudit(audit.Record{
+		Type:   audit.RECORD_TYPE_LOCK,
+		User:   lock.owner,
+		Method: method,
+		Path:   lock.root,
+		Detail: map[string]string{
+			"token":           lock.token,
+			"owner":           lock.owner,
+			"scope":           lock.scope,
+			"isDepthInfinity": strconv.FormatBool(lock.isDepthInfinity),
+			"expiresAt":       expiresAt,
+		},
+	})
+}
+
+/*
+리소스에 걸린 잠금 중 해당 토큰의 잠금 반환
+*/
+func findLock(resource *ResourceObject, lockToken string) (ResourceLock, bool) {
+	for _, lock := range resource.GetLocks() {
+		if lock.token == lockToken {
+			return lock, true
+		}
+	}
+	return ResourceLock{}, false
+}
@@ -0,0 +1,298 @@
+package class
+
+import (
+	util "app/util"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	EVENT_TYPE_CREATED         = "created"
+	EVENT_TYPE_DELETED         = "deleted"
+	EVENT_TYPE_MOVED           = "moved"
+	EVENT_TYPE_CONTENT_CHANGED = "content-changed"
+	EVENT_TYPE_ACL_CHANGED     = "acl-changed"
+	EVENT_TYPE_LOCKED          = "locked"
+	EVENT_TYPE_UNLOCKED        = "unlocked"
+)
+
+// 이벤트 종류 목록
+var KnownEventTypes = []string{
+	EVENT_TYPE_CREATED,
+	EVENT_TYPE_DELETED,
+	EVENT_TYPE_MOVED,
+	EVENT_TYPE_CONTENT_CHANGED,
+	EVENT_TYPE_ACL_CHANGED,
+	EVENT_TYPE_LOCKED,
+	EVENT_TYPE_UNLOCKED,
+}
+
+// 다시 보내기 위해 보관하는 최근 이벤트 기본 개수
+const DEFAULT_EVENT_BUFFER_SIZE = 1000
+
+// 구독자마다 전달하지 못하고 쌓아둘 수 있는 이벤트 개수
+const eventSubscriptionBufferSize = 256
+
+/*
+트리 변경 이벤트
+*/
+type ResourceEvent struct {
+	// 1부터 증가하는 이벤트 번호
+	id        int64
+	eventType string
+	path      string
+	// 옮긴 경로, EVENT_TYPE_MOVED가 아니면 빈 문자열
+	destinationPath string
+	isDirectory     bool
+	// 변경한 유저, 알 수 없으면 빈 문자열
+	user       string
+	requestId  string
+	occurredAt time.Time
+	// 이벤트 발생 시점의 리소스 권한, 이벤트를 볼 수 있는 구독자 확인에 사용
+	userPermissionMap  map[string]([]string)
+	groupPermissionMap map[string]([]string)
+}
+
+func (e ResourceEvent) GetId() int64 {
+	return e.id
+}
+
+func (e ResourceEvent) GetType() string {
+	return e.eventType
+}
+
+func (e ResourceEvent) GetPath() string {
+	return e.path
+}
+
+func (e ResourceEvent) GetDestinationPath() string {
+	return e.destinationPath
+}
+
+func (e ResourceEvent) GetUser() string {
+	return e.user
+}
+
+func (e ResourceEvent) GetRequestId() string {
+	return e.requestId
+}
+
+func (e ResourceEvent) GetOccurredAt() time.Time {
+	return e.occurredAt
+}
+
+/*
+유저 또는 유저가 속한 그룹이 이벤트 발생 시점에 리소스의 "read" 권한을 가지고 있었는지 여부 반환
+*/
+func (e ResourceEvent) CanRead(username string, groupname string) bool {
+	resource := ResourceObject{
+		userPermissionMap:  e.userPermissionMap,
+		groupPermissionMap: e.groupPermissionMap,
+	}
+	return resource.CheckPermission(username, groupname, "read")
+}
+
+/*
+이벤트가 경로 아래의 리소스에 대한 이벤트인지 여부 반환
+상위 폴더의 삭제, 이동은 경로 아래의 리소스도 바꾸므로 포함
+*/
+func (e ResourceEvent) IsUnder(path string) bool {
+	if isPathUnder(e.path, path) || (e.destinationPath != "" && isPathUnder(e.destinationPath, path)) {
+		return true
+	}
+	if e.eventType == EVENT_TYPE_DELETED || e.eventType == EVENT_TYPE_MOVED {
+		return isPathUnder(path, e.path)
+	}
+	return false
+}
+
+/*
+path가 parentPath와 같거나 그 하위 경로인지 여부 반환
+*/
+func isPathUnder(path string, parentPath string) bool {
+	if parentPath == "/" || path == parentPath {
+		return true
+	}
+	return strings.HasPrefix(path, strings.TrimSuffix(parentPath, "/")+"/")
+}
+
+func (e ResourceEvent) ToMap() map[string]any {
+	eventMap := map[string]any{
+		"id":          e.id,
+		"type":        e.eventType,
+		"path":        e.path,
+		"isDirectory": e.isDirectory,
+		"user":        e.user,
+		"requestId":   e.requestId,
+		"occurredAt":  formatTime(e.occurredAt),
+	}
+	if e.eventType == EVENT_TYPE_MOVED {
+		eventMap["destinationPath"] = e.destinationPath
+	}
+	return eventMap
+}
+
+/*
+트리 변경 이벤트를 구독자에게 전달
+최근 이벤트를 정해진 개수만큼 보관하여 다시 연결한 구독자에게 놓친 이벤트를 다시 보냄
+*/
+type EventBus struct {
+	mutex  sync.Mutex
+	lastId int64
+	// 보관한 최근 이벤트, 오래된 순서
+	events      []ResourceEvent
+	bufferSize  int
+	subscribers map[*EventSubscription]bool
+}
+
+/*
+EventBus 생성자 함수
+  - @param {int} bufferSize 다시 보내기 위해 보관할 최근 이벤트 개수
+*/
+func NewEventBus(bufferSize int) *EventBus {
+	if bufferSize < 0 {
+		bufferSize = 0
+	}
+	return &EventBus{
+		events:      []ResourceEvent{},
+		bufferSize:  bufferSize,
+		subscribers: map[*EventSubscription]bool{},
+	}
+}
+
+/*
+이벤트 구독
+구독자가 이벤트를 받지 못해 쌓인 이벤트가 너무 많으면 구독을 끊고 채널을 닫음
+  - @param {int64} lastEventId 마지막으로 받은 이벤트 번호, 음수이면 다시 보내지 않음
+  - @return {[]ResourceEvent} lastEventId 이후 놓친 이벤트 목록
+  - @return {bool} 놓친 이벤트를 모두 보관하고 있는지 여부, false이면 놓친 이벤트 목록은 비어있음
+*/
+func (b *EventBus) Subscribe(lastEventId int64) (*EventSubscription, []ResourceEvent, bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	subscription := &EventSubscription{
+		bus:     b,
+		channel: make(chan ResourceEvent, eventSubscriptionBufferSize),
+		startId: b.lastId,
+	}
+	b.subscribers[subscription] = true
+
+	if lastEventId < 0 {
+		return subscription, []ResourceEvent{}, true
+	}
+	// 보관한 이벤트보다 오래되었거나, 번호가 다시 시작된 경우
+	oldestId := b.lastId - int64(len(b.events)) + 1
+	if lastEventId < oldestId-1 || lastEventId > b.lastId {
+		return subscription, []ResourceEvent{}, false
+	}
+	missedEvents := util.CloneSlice(b.events[lastEventId-oldestId+1:])
+	return subscription, missedEvents, true
+}
+
+/*
+이벤트에 번호를 붙여 보관하고 구독자에게 전달
+  - @return {ResourceEvent} 번호를 붙인 이벤트
+*/
+func (b *EventBus) Publish(event ResourceEvent) ResourceEvent {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.lastId++
+	event.id = b.lastId
+	if b.bufferSize > 0 {
+		if len(b.events) >= b.bufferSize {
+			b.events = b.events[len(b.events)-b.bufferSize+1:]
+		}
+		b.events = append(b.events, event)
+	}
+
+	for subscription := range b.subscribers {
+		select {
+		case subscription.channel <- event:
+		default:
+			b.unsubscribe(subscription)
+		}
+	}
+	return event
+}
+
+/*
+마지막 이벤트 번호 반환, 이벤트가 없으면 0
+*/
+func (b *EventBus) GetLastId() int64 {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.lastId
+}
+
+func (b *EventBus) unsubscribe(subscription *EventSubscription) {
+	if !b.subscribers[subscription] {
+		return
+	}
+	delete(b.subscribers, subscription)
+	close(subscription.channel)
+}
+
+/*
+이벤트 구독
+*/
+type EventSubscription struct {
+	bus     *EventBus
+	channel chan ResourceEvent
+	// 구독 시점의 마지막 이벤트 번호, 채널로는 이후 번호의 이벤트만 전달됨
+	startId int64
+}
+
+/*
+이벤트를 받을 채널 반환
+구독이 끊기면 채널이 닫힘
+*/
+func (s *EventSubscription) GetChannel() <-chan ResourceEvent {
+	return s.channel
+}
+
+/*
+구독 시점의 마지막 이벤트 번호 반환
+*/
+func (s *EventSubscription) GetStartId() int64 {
+	return s.startId
+}
+
+/*
+구독 해제
+*/
+func (s *EventSubscription) Close() {
+	s.bus.mutex.Lock()
+	defer s.bus.mutex.Unlock()
+	s.bus.unsubscribe(s)
+}
+
+/*
+트리 변경 이벤트 버스 반환
+*/
+func (m *ResourceManager) GetEventBus() *EventBus {
+	return m.eventBus
+}
+
+/*
+리소스 변경 이벤트 발생
+권한은 이벤트 발생 시점의 리소스 권한을 복사하여 보관
+  - @param {string} path 리소스 경로, 삭제한 리소스는 삭제 전 경로
+  - @param {string} destinationPath 옮긴 경로, EVENT_TYPE_MOVED가 아니면 빈 문자열
+  - @param {string} user 변경한 유저, 알 수 없으면 빈 문자열
+*/
+func (m *ResourceManager) emitEvent(eventType string, resource *ResourceObject, path string, destinationPath string, user string) {
+	m.eventBus.Publish(ResourceEvent{
+		eventType:          eventType,
+		path:               path,
+		destinationPath:    destinationPath,
+		isDirectory:        resource.isDirectory,
+		user:               user,
+		requestId:          util.GetRequestId(m.GetContext()),
+		occurredAt:         time.Now(),
+		userPermissionMap:  clonePermissionMap(resource.userPermissionMap),
+		groupPermissionMap: clonePermissionMap(resource.groupPermissionMap),
+	})
+}
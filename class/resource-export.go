@@ -0,0 +1,269 @@
+package class
+
+import (
+	storage "app/storage"
+	util "app/util"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+/*
+경로의 리소스와 하위 리소스를 내보내기
+트리 구조, 권한, 속성, 메타데이터와 파일의 현재 내용을 포함하며 잠금과 이전 버전은 가져올 때 제외됨
+  - @return {map[string]any} `resource`: 리소스 map, `contents`: 내용의 해시별 base64 문자열
+*/
+func (m *ResourceManager) ExportResource(path string) (map[string]any, error) {
+	m, unlock := m.rlockTree()
+	defer unlock()
+
+	if !util.IsValidPath(path) {
+		return nil, ErrInvalidPath
+	}
+	resource := m.GetResourceObject(path)
+	if resource == nil {
+		return nil, ErrNotFound
+	}
+
+	// 저장소에 없는 내용은 제외
+	contents := map[string]any{}
+	err := m.Walk(path, func(current *ResourceObject, depth int) error {
+		if !current.IsFile() || contents[current.contentHash] != nil {
+			return nil
+		}
+		reader, err := m.contentStore.Open(current.GetContentHash())
+		if err != nil {
+			return nil
+		}
+		defer reader.Close()
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			return err
+		}
+		contents[current.GetContentHash()] = base64.StdEncoding.EncodeToString(data)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]any{
+		"resource": resource.ToMap(),
+		"contents": contents,
+	}, nil
+}
+
+/*
+잠금을 확인하며 내보낸 리소스를 경로로 가져오기
+가져온 리소스는 내보낼 때의 권한, 생성한 유저를 유지하며 잠금과 이전 버전은 가져오지 않음
+경로에 리소스가 있으면 isOverwrite가 true일 때만 덮어쓰며, 덮어쓴 리소스는 휴지통을 사용하면 휴지통으로 옮김
+형식이 올바르지 않으면 ErrInvalidData
+  - @param {map[string]any} exportMap ExportResource가 반환한 map
+  - @return {bool} 경로의 리소스를 덮어썼는지 여부
+*/
+func (m *ResourceManager) ImportResourceWithLockTokens(path string, exportMap map[string]any, isOverwrite bool, modifier string, lockTokens []string) (bool, error) {
+	m, unlock, err := m.lockTree()
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+
+	resourceMap, ok := exportMap["resource"].(map[string]any)
+	if !ok || !isValidResourceObjectMap(resourceMap) {
+		return false, ErrInvalidData
+	}
+	contents := map[string][]byte{}
+	contentMaps, _ := exportMap["contents"].(map[string]any)
+	for hash, value := range contentMaps {
+		encoded, ok := value.(string)
+		if !ok {
+			return false, ErrInvalidData
+		}
+		data, err := base64.StdEncoding.DecodeString(encoded)
+		if !storage.IsValidHash(hash) || err != nil {
+			return false, ErrInvalidData
+		}
+		contents[hash] = data
+	}
+
+	if !util.IsValidPath(path) || path == "/" {
+		return false, ErrInvalidPath
+	}
+	parentPath, _ := util.GetParentDirectory(path)
+	parentResource := m.GetResourceObject(parentPath)
+	if parentResource == nil {
+		return false, ErrParentNotFound
+	}
+	if !parentResource.IsDirectory() {
+		return false, ErrNotDirectory
+	}
+
+	destinationResource := m.GetResourceObject(path)
+	if destinationResource != nil && !isOverwrite {
+		return false, ErrAlreadyExists
+	}
+
+	// 잠금 확인
+	if !parentResource.CanModify(lockTokens) ||
+		(destinationResource != nil && !destinationResource.CanModifyRecursive(lockTokens)) {
+		return false, ErrLocked
+	}
+
+	// 파일의 크기는 내보낸 값 대신 내용으로부터 다시 계산
+	importedResource := FromMapResourceObject(resourceMap).clone()
+	if !applyImportedContents(importedResource, contents) {
+		return false, ErrInvalidData
+	}
+	err = m.checkQuota(quotaChange{
+		parent:  parentResource,
+		bytes:   importedResource.size,
+		nodes:   importedResource.countNodes(),
+		removed: destinationResource,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	// 내용의 해시가 맞는지 확인하며 저장
+	for hash, data := range contents {
+		storedHash, _, err := m.contentStore.Put(bytes.NewReader(data))
+		if err != nil {
+			return false, err
+		}
+		if storedHash != hash {
+			m.CollectGarbage()
+			return false, ErrInvalidData
+		}
+	}
+
+	// 덮어쓸 리소스 삭제 후 가져오기
+	if destinationResource != nil && m.trashPolicy.IsEnabled {
+		m.moveToTrash(destinationResource, modifier)
+	} else if destinationResource != nil {
+		m.unindexTree(destinationResource)
+		m.emitEvent(EVENT_TYPE_DELETED, destinationResource, path, "", modifier)
+		parentResource.DeleteChild(destinationResource.name)
+	}
+	names := strings.Split(path, "/")
+	parentResource.AttachChild(names[len(names)-1], importedResource)
+	m.indexTree(importedResource)
+	m.emitEvent(EVENT_TYPE_CREATED, importedResource, path, "", modifier)
+	parentResource.touch(modifier)
+	m.CollectGarbage()
+	return destinationResource != nil, nil
+}
+
+/*
+가져온 리소스의 내용 해시가 내보낸 내용에 포함되어 있는지 확인하고 크기를 내용으로부터 다시 계산
+내용을 쓴 적이 없는 파일은 해시가 비어있으며 크기는 0
+  - @return {bool} 모든 파일의 내용이 포함되어 있으면 true
+*/
+func applyImportedContents(resource *ResourceObject, contents map[string][]byte) bool {
+	if resource.IsFile() {
+		if resource.contentHash == "" {
+			resource.size = 0
+			return true
+		}
+		data, ok := contents[resource.contentHash]
+		if !ok {
+			return false
+		}
+		resource.size = int64(len(data))
+		return true
+	}
+
+	resource.size = 0
+	for _, child := range resource.childrenMap {
+		if !applyImportedContents(child, contents) {
+			return false
+		}
+		resource.size += child.size
+	}
+	return true
+}
+
+/*
+FromMapResourceObject로 변환할 수 있는 리소스 map인지 확인
+*/
+func isValidResourceObjectMap(resourceObjectMap map[string]any) bool {
+	if _, ok := resourceObjectMap["isDirectory"].(bool); !ok {
+		return false
+	}
+	if _, ok := resourceObjectMap["path"].(string); !ok {
+		return false
+	}
+	if _, ok := resourceObjectMap["name"].(string); !ok {
+		return false
+	}
+	// 내용의 해시는 ETag 계산과 저장소 경로에 사용
+	if value, ok := resourceObjectMap["contentHash"]; ok && value != nil {
+		contentHash, ok := value.(string)
+		if !ok || (contentHash != "" && !storage.IsValidHash(contentHash)) {
+			return false
+		}
+	}
+	for _, key := range []string{"userPermissionMap", "groupPermissionMap"} {
+		permissionMap, ok := resourceObjectMap[key].(map[string]any)
+		if !ok {
+			return false
+		}
+		for _, value := range permissionMap {
+			permissions, ok := value.([]any)
+			if !ok {
+				return false
+			}
+			for _, permission := range permissions {
+				if _, ok := permission.(string); !ok {
+					return false
+				}
+			}
+		}
+	}
+	if value, ok := resourceObjectMap["propertyMap"]; ok && value != nil {
+		namespaceMaps, ok := value.(map[string]any)
+		if !ok {
+			return false
+		}
+		for _, namespaceValue := range namespaceMaps {
+			properties, ok := namespaceValue.(map[string]any)
+			if !ok {
+				return false
+			}
+			for _, propertyValue := range properties {
+				if _, ok := propertyValue.(string); !ok {
+					return false
+				}
+			}
+		}
+	}
+
+	childrenMap, ok := resourceObjectMap["childrenMap"].(map[string]any)
+	if !ok {
+		return false
+	}
+	for name, value := range childrenMap {
+		childMap, ok := value.(map[string]any)
+		if name == "" || strings.Contains(name, "/") || !ok || !isValidResourceObjectMap(childMap) {
+			return false
+		}
+	}
+	return true
+}
+
+/*
+JSON으로 ResourceManager 생성
+형식이 올바르지 않으면 ErrInvalidData
+*/
+func ParseJsonResourceManager(jsonData string) (*ResourceManager, error) {
+	var resourceManagerMap map[string]any
+	if err := json.Unmarshal([]byte(jsonData), &resourceManagerMap); err != nil {
+		return nil, ErrInvalidData
+	}
+	rootResourceMap, ok := resourceManagerMap["rootResource"].(map[string]any)
+	if !ok || !isValidResourceObjectMap(rootResourceMap) {
+		return nil, ErrInvalidData
+	}
+	return FromJsonResourceManager(jsonData), nil
+}
@@ -0,0 +1,130 @@
+package class
+
+import (
+	"time"
+)
+
+const (
+	LOCK_SCOPE_EXCLUSIVE = "exclusive"
+	LOCK_SCOPE_SHARED    = "shared"
+)
+
+type ResourceLock struct {
+	token           string
+	owner           string
+	scope           string
+	root            string
+	isDepthInfinity bool
+	expiresAt       time.Time
+}
+
+type LockParam struct {
+	IsDepthInfinity bool
+	// 비어있으면 새로 생성
+	LockToken string
+	Owner     string
+	// LOCK_SCOPE_EXCLUSIVE 또는 LOCK_SCOPE_SHARED, 비어있으면 LOCK_SCOPE_EXCLUSIVE
+	Scope string
+	// 0 이하이면 만료되지 않음
+	Timeout time.Duration
+}
+
+/*
+잠금 토큰 반환
+*/
+func (l ResourceLock) GetToken() string {
+	return l.token
+}
+
+/*
+잠금 소유자 반환
+*/
+func (l ResourceLock) GetOwner() string {
+	return l.owner
+}
+
+/*
+잠금 범위 반환 (LOCK_SCOPE_EXCLUSIVE | LOCK_SCOPE_SHARED)
+*/
+func (l ResourceLock) GetScope() string {
+	return l.scope
+}
+
+/*
+잠금을 건 리소스의 경로 반환
+*/
+func (l ResourceLock) GetRoot() string {
+	return l.root
+}
+
+/*
+하위 리소스까지 잠그는지 여부 반환
+*/
+func (l ResourceLock) IsDepthInfinity() bool {
+	return l.isDepthInfinity
+}
+
+/*
+만료 시각 반환
+만료되지 않는 잠금이면 zero value
+*/
+func (l ResourceLock) GetExpiresAt() time.Time {
+	return l.expiresAt
+}
+
+/*
+해당 시각에 잠금이 만료되었는지 여부 반환
+*/
+func (l ResourceLock) IsExpired(now time.Time) bool {
+	return !l.expiresAt.IsZero() && !now.Before(l.expiresAt)
+}
+
+/*
+Map화
+*/
+func (l ResourceLock) ToMap() map[string]any {
+	return map[string]any{
+		"token":           l.token,
+		"owner":           l.owner,
+		"scope":           l.scope,
+		"root":            l.root,
+		"isDepthInfinity": l.isDepthInfinity,
+		"expiresAt":       formatTime(l.expiresAt),
+	}
+}
+
+/*
+map으로부터 잠금을 생성
+형식이 올바르지 않으면 false
+*/
+func FromMapResourceLock(lockMap map[string]any) (ResourceLock, bool) {
+	token, ok := lockMap["token"].(string)
+	if !ok || token == "" {
+		return ResourceLock{}, false
+	}
+	owner, _ := lockMap["owner"].(string)
+	scope, _ := lockMap["scope"].(string)
+	if scope != LOCK_SCOPE_SHARED {
+		scope = LOCK_SCOPE_EXCLUSIVE
+	}
+	root, _ := lockMap["root"].(string)
+	isDepthInfinity, _ := lockMap["isDepthInfinity"].(bool)
+
+	var expiresAt time.Time
+	if expiresAtString, _ := lockMap["expiresAt"].(string); expiresAtString != "" {
+		var err error
+		expiresAt, err = time.Parse(time.RFC3339Nano, expiresAtString)
+		if err != nil {
+			return ResourceLock{}, false
+		}
+	}
+
+	return ResourceLock{
+		token:           token,
+		owner:           owner,
+		scope:           scope,
+		root:            root,
+		isDepthInfinity: isDepthInfinity,
+		expiresAt:       expiresAt,
+	}, true
+}
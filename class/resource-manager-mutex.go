@@ -0,0 +1,47 @@
+package class
+
+// ResourceManager가 잡고 있는 트리 잠금
+const (
+	treeMutexNone = iota
+	treeMutexRead
+	treeMutexWrite
+)
+
+/*
+트리 쓰기 잠금을 걸고 잠금을 잡은 ResourceManager와 해제 함수 반환
+잠금을 잡은 ResourceManager로 호출한 메소드는 다시 잠그지 않음
+읽기 잠금만 잡은 상태에서는 트리를 변경할 수 없으므로 ErrReadOnlyView
+*/
+func (m *ResourceManager) lockTree() (*ResourceManager, func(), error) {
+	switch m.treeMutex {
+	case treeMutexWrite:
+		return m, func() {}, nil
+	case treeMutexRead:
+		return nil, nil, ErrReadOnlyView
+	}
+	m.mutex.Lock()
+	return &ResourceManager{resourceManagerState: m.resourceManagerState, ctx: m.ctx, treeMutex: treeMutexWrite}, m.mutex.Unlock, nil
+}
+
+/*
+트리 읽기 잠금을 걸고 잠금을 잡은 ResourceManager와 해제 함수 반환
+이미 잠금을 잡은 ResourceManager이면 다시 잠그지 않음
+*/
+func (m *ResourceManager) rlockTree() (*ResourceManager, func()) {
+	if m.treeMutex != treeMutexNone {
+		return m, func() {}
+	}
+	m.mutex.RLock()
+	return &ResourceManager{resourceManagerState: m.resourceManagerState, ctx: m.ctx, treeMutex: treeMutexRead}, m.mutex.RUnlock
+}
+
+/*
+트리 읽기 잠금을 잡은 상태로 fn 실행
+ResourceManager가 반환한 리소스 객체를 읽을 때 사용하며, 리소스 객체는 fn 안에서만 읽어야 함
+fn 안에서는 인자로 받은 ResourceManager만 사용해야 하며, 트리를 변경하는 메소드는 ErrReadOnlyView를 반환하거나 실패
+*/
+func (m *ResourceManager) View(fn func(m *ResourceManager)) {
+	m, unlock := m.rlockTree()
+	defer unlock()
+	fn(m)
+}
@@ -1,26 +1,100 @@
 package class
 
 import (
+	audit "app/audit"
+	storage "app/storage"
+	util "app/util"
+	"context"
 	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
+/*
+리소스 트리 관리
+여러 goroutine에서 동시에 사용할 수 있으며, 반환한 리소스 객체는 다른 요청이 변경할 수 있으므로 View 안에서 읽어야 함
+*/
 type ResourceManager struct {
-	rootResource *ResourceObject
+	*resourceManagerState
+	// 작업을 요청한 context, 요청 ID를 감사 기록에 전달
+	ctx context.Context
+	// 이 ResourceManager가 잡고 있는 트리 잠금, lockTree, rlockTree 참고
+	treeMutex int
+}
+
+/*
+ResourceManager의 상태
+WithContext로 만든 ResourceManager끼리 공유
+*/
+type resourceManagerState struct {
+	// 트리와 아래 상태를 보호, 트리를 변경하는 메소드는 쓰기 잠금, 조회하는 메소드는 읽기 잠금을 잡음
+	mutex         sync.RWMutex
+	rootResource  *ResourceObject
+	contentStore  storage.BlobStore
+	versionPolicy VersionPolicy
+	trashItems    []TrashItem
+	trashPolicy   TrashPolicy
+	snapshots     []ResourceSnapshot
+	userQuotas    map[string]ResourceQuota
+	groupQuotas   map[string]ResourceQuota
+	// 유저, 그룹에서 권한 항목이 있는 리소스로의 역색인
+	permissionIndex *permissionIndex
+	// 유저, 그룹별로 생성한 리소스의 사용량
+	ownerUsageIndex *ownerUsageIndex
+	// 권한, 잠금 변경을 기록할 감사 기록기, nil이면 기록하지 않음
+	auditLogger *audit.Logger
+	// 트리 변경 이벤트 버스
+	eventBus *EventBus
+	// 잠금 없이 저장소에 내용을 쓰고 있는 작업 수, 0보다 크면 CollectGarbage가 내용을 삭제하지 않음
+	pendingWrites atomic.Int64
+}
+
+/*
+같은 트리를 다루며 작업에 context를 전달하는 ResourceManager 반환
+context의 요청 ID는 감사 기록에 남음
+*/
+func (m *ResourceManager) WithContext(ctx context.Context) *ResourceManager {
+	return &ResourceManager{
+		resourceManagerState: m.resourceManagerState,
+		ctx:                  ctx,
+		treeMutex:            m.treeMutex,
+	}
+}
+
+/*
+작업에 전달된 context 반환
+*/
+func (m *ResourceManager) GetContext() context.Context {
+	if m.ctx == nil {
+		return context.Background()
+	}
+	return m.ctx
 }
 
 // 특정 경로의 리소스 객체의 포인터를 반환
-func (m ResourceManager) GetResourceObject(path string) *ResourceObject {
-	if path[0] != '/' {
+func (m *ResourceManager) GetResourceObject(path string) *ResourceObject {
+	m, unlock := m.rlockTree()
+	defer unlock()
+	return getResourceObjectFrom(m.rootResource, path)
+}
+
+// 루트 리소스로부터 특정 경로의 리소스 객체의 포인터를 반환
+func getResourceObjectFrom(rootResource *ResourceObject, path string) *ResourceObject {
+	if path == "" || path[0] != '/' {
 		return nil
 	}
 
 	if path == "/" {
-		return m.rootResource
+		return rootResource
 	}
 
 	names := strings.Split(path, "/")
-	currentResource := m.rootResource
+	currentResource := rootResource
 	for i, name := range names {
 		if i == 0 {
 			continue
@@ -42,7 +116,10 @@ func (m ResourceManager) GetResourceObject(path string) *ResourceObject {
 /*
 경로에 대해 특정 유저가 특정 권한을 가지고 있는지 확인
 */
-func (m ResourceManager) CheckUserPermission(path string, username string, permission string) bool {
+func (m *ResourceManager) CheckUserPermission(path string, username string, permission string) bool {
+	m, unlock := m.rlockTree()
+	defer unlock()
+
 	resource := m.GetResourceObject(path)
 
 	if resource == nil {
@@ -55,13 +132,19 @@ func (m ResourceManager) CheckUserPermission(path string, username string, permi
 /*
 경로에 대해 특정 그룹이 특정 권한을 가지고 있는지 확인
 */
-func (m ResourceManager) CheckGroupPermission(path string, groupname string, permission string) bool {
+func (m *ResourceManager) CheckGroupPermission(path string, groupname string, permission string) bool {
+	m, unlock := m.rlockTree()
+	defer unlock()
+
 	resource := m.GetResourceObject(path)
 
 	return resource.CheckGroupPermission(groupname, permission)
 }
 
 func (m *ResourceManager) GetUserPermissions(path string, username string) []string {
+	m, unlock := m.rlockTree()
+	defer unlock()
+
 	resource := m.GetResourceObject(path)
 
 	return resource.GetUserPermissions(username)
@@ -71,32 +154,58 @@ func (m *ResourceManager) GetUserPermissions(path string, username string) []str
 경로에 특정 유저의 권한 추가
 */
 func (m *ResourceManager) AddUserPermission(path string, username string, permission string) []string {
+	m, unlock, err := m.lockTree()
+	if err != nil {
+		return []string{}
+	}
+	defer unlock()
+
 	resource := m.GetResourceObject(path)
 
 	if resource == nil {
 		return []string{}
 	}
 
-	return resource.AddUserPermission(username, permission)
+	permissions := resource.AddUserPermission(username, permission)
+	m.permissionIndex.updateUser(username, resource)
+	m.logAclChange("AddUserPermission", resource.GetPath(), "", PRINCIPAL_TYPE_USER, username, permission)
+	m.emitEvent(EVENT_TYPE_ACL_CHANGED, resource, resource.GetPath(), "", "")
+	return permissions
 }
 
 /*
 경로에 특정 그룹의 권한 추가
 */
 func (m *ResourceManager) AddGroupPermission(path string, groupname string, permission string) []string {
+	m, unlock, err := m.lockTree()
+	if err != nil {
+		return []string{}
+	}
+	defer unlock()
+
 	resource := m.GetResourceObject(path)
 
 	if resource == nil {
 		return []string{}
 	}
 
-	return resource.AddGroupPermission(groupname, permission)
+	permissions := resource.AddGroupPermission(groupname, permission)
+	m.permissionIndex.updateGroup(groupname, resource)
+	m.logAclChange("AddGroupPermission", resource.GetPath(), "", PRINCIPAL_TYPE_GROUP, groupname, permission)
+	m.emitEvent(EVENT_TYPE_ACL_CHANGED, resource, resource.GetPath(), "", "")
+	return permissions
 }
 
 /*
 경로에 특정 유저의 권한 삭제
 */
 func (m *ResourceManager) DeleteUserPermission(path string, username string, permission string) {
+	m, unlock, err := m.lockTree()
+	if err != nil {
+		return
+	}
+	defer unlock()
+
 	resource := m.GetResourceObject(path)
 
 	if resource == nil {
@@ -104,12 +213,21 @@ func (m *ResourceManager) DeleteUserPermission(path string, username string, per
 	}
 
 	resource.DeleteUserPermission(username, permission)
+	m.permissionIndex.updateUser(username, resource)
+	m.logAclChange("DeleteUserPermission", resource.GetPath(), "", PRINCIPAL_TYPE_USER, username, permission)
+	m.emitEvent(EVENT_TYPE_ACL_CHANGED, resource, resource.GetPath(), "", "")
 }
 
 /*
 경로에 특정 그룹의 권한 삭제
 */
 func (m *ResourceManager) DeleteGroupPermission(path string, groupname string, permission string) {
+	m, unlock, err := m.lockTree()
+	if err != nil {
+		return
+	}
+	defer unlock()
+
 	resource := m.GetResourceObject(path)
 
 	if resource == nil {
@@ -117,6 +235,9 @@ func (m *ResourceManager) DeleteGroupPermission(path string, groupname string, p
 	}
 
 	resource.DeleteGroupPermission(groupname, permission)
+	m.permissionIndex.updateGroup(groupname, resource)
+	m.logAclChange("DeleteGroupPermission", resource.GetPath(), "", PRINCIPAL_TYPE_GROUP, groupname, permission)
+	m.emitEvent(EVENT_TYPE_ACL_CHANGED, resource, resource.GetPath(), "", "")
 }
 
 /*
@@ -125,36 +246,227 @@ func (m *ResourceManager) DeleteGroupPermission(path string, groupname string, p
   - @return {string} 잠금 토큰
 */
 func (m *ResourceManager) Lock(path string, isDepthInfinity bool) (bool, string) {
+	m, unlock, err := m.lockTree()
+	if err != nil {
+		return false, ""
+	}
+	defer unlock()
+
+	resource := m.GetResourceObject(path)
+	if resource == nil {
+		return false, ""
+	}
+
+	success, lockToken := resource.Lock(isDepthInfinity, "")
+	if lock, ok := findLock(resource, lockToken); success && ok {
+		m.logLockChange("Lock", lock)
+		m.emitEvent(EVENT_TYPE_LOCKED, resource, resource.GetPath(), "", lock.owner)
+	}
+	return success, lockToken
+}
+
+/*
+경로에 해당하는 리소스를 소유자, 범위, 만료 시간을 지정하여 잠금
+  - @return {bool} 잠금 성공 여부
+  - @return {string} 잠금 토큰
+*/
+func (m *ResourceManager) LockWithParam(path string, param LockParam) (bool, string) {
+	m, unlock, err := m.lockTree()
+	if err != nil {
+		return false, ""
+	}
+	defer unlock()
+
 	resource := m.GetResourceObject(path)
 	if resource == nil {
 		return false, ""
 	}
 
-	return resource.Lock(isDepthInfinity, "")
+	success, lockToken := resource.LockWithParam(param)
+	if lock, ok := findLock(resource, lockToken); success && ok {
+		m.logLockChange("Lock", lock)
+		m.emitEvent(EVENT_TYPE_LOCKED, resource, resource.GetPath(), "", lock.owner)
+	}
+	return success, lockToken
+}
+
+/*
+경로에 해당하는 리소스의 잠금 만료 시간 갱신
+*/
+func (m *ResourceManager) RefreshLock(path string, lockToken string, timeout time.Duration) bool {
+	m, unlock, err := m.lockTree()
+	if err != nil {
+		return false
+	}
+	defer unlock()
+
+	resource := m.getLockRootResourceObject(path, lockToken)
+	if resource == nil {
+		return false
+	}
+
+	if !resource.RefreshLock(lockToken, timeout) {
+		return false
+	}
+	if lock, ok := findLock(resource, lockToken); ok {
+		m.logLockChange("RefreshLock", lock)
+	}
+	return true
+}
+
+/*
+경로의 리소스에 걸린 잠금 중 해당 토큰의 잠금을 건 리소스 반환
+하위 리소스까지 잠근 잠금은 하위 리소스에서도 잠금 전체를 해제, 갱신할 수 있도록 하기 위함
+*/
+func (m *ResourceManager) getLockRootResourceObject(path string, lockToken string) *ResourceObject {
+	resource := m.GetResourceObject(path)
+	if resource == nil {
+		return nil
+	}
+
+	for _, lock := range resource.GetLocks() {
+		if lock.token != lockToken {
+			continue
+		}
+		rootResource := m.GetResourceObject(lock.root)
+		if rootResource != nil {
+			return rootResource
+		}
+		break
+	}
+	return resource
 }
 
 /*
 경로에 해당하는 리소스 잠금 해제
 */
 func (m *ResourceManager) Unlock(path string, lockToken string) bool {
-	resource := m.GetResourceObject(path)
+	m, unlock, err := m.lockTree()
+	if err != nil {
+		return false
+	}
+	defer unlock()
+
+	resource := m.getLockRootResourceObject(path, lockToken)
 	if resource == nil {
 		return false
 	}
 
-	return resource.Unlock(lockToken)
+	lock, ok := findLock(resource, lockToken)
+	if !resource.Unlock(lockToken) {
+		return false
+	}
+	if ok {
+		m.logLockChange("Unlock", lock)
+		m.emitEvent(EVENT_TYPE_UNLOCKED, resource, resource.GetPath(), "", lock.owner)
+	}
+	return true
 }
 
 /*
 경로에 해당하는 리소스 강제 잠금 해제
+  - @return {bool} 해제한 잠금이 있는지 여부
 */
 func (m *ResourceManager) UnlockForce(path string) bool {
+	locks, err := m.UnlockForceWithResult(path)
+	return err == nil && len(locks) > 0
+}
+
+/*
+경로의 리소스에 걸린 모든 잠금 강제 해제
+상위 폴더에 건 잠금은 잠금을 건 리소스에서 해제하므로 다른 하위 리소스에서도 해제됨
+경로에 리소스가 없으면 ErrNotFound
+  - @return {[]ResourceLock} 해제한 잠금 목록
+*/
+func (m *ResourceManager) UnlockForceWithResult(path string) ([]ResourceLock, error) {
+	m, unlock, err := m.lockTree()
+	if err != nil {
+		return []ResourceLock{}, err
+	}
+	defer unlock()
+
 	resource := m.GetResourceObject(path)
 	if resource == nil {
-		return false
+		return nil, ErrNotFound
+	}
+
+	locks := resource.GetLocks()
+	for _, lock := range locks {
+		rootResource := m.getLockRootResourceObject(path, lock.token)
+		rootResource.removeLock(lock.token)
+		m.logLockChange("UnlockForce", lock)
+		m.emitEvent(EVENT_TYPE_UNLOCKED, rootResource, rootResource.GetPath(), "", "")
+	}
+	return locks, nil
+}
+
+/*
+트리 전체에 걸린 잠금 목록 반환
+owner가 비어있지 않으면 해당 유저가 소유한 잠금만 반환
+잠금을 건 경로, 토큰 순으로 정렬
+*/
+func (m *ResourceManager) GetActiveLocks(owner string) []ResourceLock {
+	m, unlock := m.rlockTree()
+	defer unlock()
+
+	locksMap := map[string]ResourceLock{}
+	m.rootResource.collectLocks(locksMap)
+
+	locks := []ResourceLock{}
+	for _, lock := range locksMap {
+		if owner != "" && lock.owner != owner {
+			continue
+		}
+		locks = append(locks, lock)
+	}
+	sort.Slice(locks, func(i, j int) bool {
+		if locks[i].root != locks[j].root {
+			return locks[i].root < locks[j].root
+		}
+		return locks[i].token < locks[j].token
+	})
+	return locks
+}
+
+/*
+트리 전체의 잠금을 강제 해제
+owner가 비어있지 않으면 해당 유저가 소유한 잠금만 해제
+  - @return {[]ResourceLock} 해제한 잠금 목록
+*/
+func (m *ResourceManager) UnlockForceAll(owner string) []ResourceLock {
+	m, unlock, err := m.lockTree()
+	if err != nil {
+		return []ResourceLock{}
+	}
+	defer unlock()
+
+	// 잠금을 건 경로별로 묶어서 해제
+	locks := m.GetActiveLocks(owner)
+	locksByRoot := map[string][]ResourceLock{}
+	roots := []string{}
+	for _, lock := range locks {
+		if locksByRoot[lock.root] == nil {
+			roots = append(roots, lock.root)
+		}
+		locksByRoot[lock.root] = append(locksByRoot[lock.root], lock)
 	}
 
-	return resource.UnlockForce()
+	unlockedLocks := []ResourceLock{}
+	for _, root := range roots {
+		resource := m.GetResourceObject(root)
+		if resource == nil {
+			continue
+		}
+
+		// 상위 폴더에서 상속한 잠금은 그 폴더에서 해제하므로 이 경로에 건 잠금만 해제
+		for _, lock := range locksByRoot[root] {
+			resource.removeLock(lock.token)
+			m.logLockChange("UnlockForceAll", lock)
+		}
+		unlockedLocks = append(unlockedLocks, locksByRoot[root]...)
+		m.emitEvent(EVENT_TYPE_UNLOCKED, resource, resource.GetPath(), "", owner)
+	}
+	return unlockedLocks
 }
 
 /*
@@ -163,7 +475,20 @@ func (m *ResourceManager) UnlockForce(path string) bool {
   - @return {*ResourceObject} 생성한 리소스 객체의 포인터, 실패시 nil
 */
 func (m *ResourceManager) CreateResource(path string, isDirectory bool) (bool, *ResourceObject) {
-	if path[0] != '/' || path == "/" {
+	m, unlock, err := m.lockTree()
+	if err != nil {
+		return false, nil
+	}
+	defer unlock()
+	return m.createResource(path, isDirectory, "", "")
+}
+
+/*
+경로에 리소스 생성
+새로 생성한 상위 폴더를 포함하여 생성한 유저를 기록
+*/
+func (m *ResourceManager) createResource(path string, isDirectory bool, creator string, creatorGroup string) (bool, *ResourceObject) {
+	if path == "" || path[0] != '/' || path == "/" {
 		return false, nil
 	}
 
@@ -199,6 +524,11 @@ func (m *ResourceManager) CreateResource(path string, isDirectory bool) (bool, *
 			if !result {
 				return false, nil
 			}
+			childResource.creator = creator
+			childResource.creatorGroup = creatorGroup
+			childResource.lastModifier = creator
+			m.indexTree(childResource)
+			m.emitEvent(EVENT_TYPE_CREATED, childResource, childResource.GetPath(), "", creator)
 		}
 
 		currentResource = childResource
@@ -207,12 +537,57 @@ func (m *ResourceManager) CreateResource(path string, isDirectory bool) (bool, *
 	return true, currentResource
 }
 
+/*
+상위 폴더를 생성하지 않고 경로에 리소스 생성
+상위 폴더가 없거나 파일이면 실패
+  - @return {bool} 성공 여부
+  - @return {*ResourceObject} 생성한 리소스 객체의 포인터, 실패시 nil
+*/
+func (m *ResourceManager) CreateResourceStrict(path string, isDirectory bool) (bool, *ResourceObject) {
+	m, unlock, err := m.lockTree()
+	if err != nil {
+		return false, nil
+	}
+	defer unlock()
+
+	if !util.IsValidPath(path) || path == "/" {
+		return false, nil
+	}
+
+	parentPath, _ := util.GetParentDirectory(path)
+	parentResource := m.GetResourceObject(parentPath)
+	if parentResource == nil || !parentResource.IsDirectory() {
+		return false, nil
+	}
+
+	names := strings.Split(path, "/")
+	success, resource := parentResource.CreateChild(names[len(names)-1], isDirectory)
+	if success {
+		m.indexTree(resource)
+		m.emitEvent(EVENT_TYPE_CREATED, resource, resource.GetPath(), "", "")
+	}
+	return success, resource
+}
+
 /*
 경로에 리소스 객체 삭제
   - @param {bool} 삭제 성공 여부
 */
 func (m *ResourceManager) DeleteResource(path string) bool {
-	if path[0] != '/' || path == "/" {
+	m, unlock, err := m.lockTree()
+	if err != nil {
+		return false
+	}
+	defer unlock()
+	return m.deleteResource(path, "")
+}
+
+/*
+경로에 리소스 객체 삭제
+  - @param {string} deleter 삭제한 유저, 알 수 없으면 빈 문자열
+*/
+func (m *ResourceManager) deleteResource(path string, deleter string) bool {
+	if path == "" || path[0] != '/' || path == "/" {
 		return false
 	}
 
@@ -242,58 +617,1593 @@ func (m *ResourceManager) DeleteResource(path string) bool {
 		currentResource = childResource
 	}
 
-	return currentResource.DeleteChild(names[namesLen-1])
+	resource := currentResource.GetChild(names[namesLen-1])
+	if resource == nil {
+		return false
+	}
+	m.unindexTree(resource)
+	m.emitEvent(EVENT_TYPE_DELETED, resource, resource.GetPath(), "", deleter)
+	currentResource.DeleteChild(resource.name)
+	m.CollectGarbage()
+	return true
 }
 
 /*
-Map화
+경로에 존재하는 가장 가까운 상위 리소스 반환
+경로의 리소스가 이미 존재하면 해당 리소스의 부모를 반환
 */
-func (m ResourceManager) ToMap() map[string]any {
-	resourceManagerMap := map[string]any{
-		"rootResource": m.rootResource.ToMap(),
+func (m *ResourceManager) GetNearestExistingParent(path string) *ResourceObject {
+	m, unlock := m.rlockTree()
+	defer unlock()
+
+	parentPath, err := util.GetParentDirectory(path)
+	for err == nil {
+		parentResource := m.GetResourceObject(parentPath)
+		if parentResource != nil {
+			return parentResource
+		}
+		parentPath, err = util.GetParentDirectory(parentPath)
 	}
-	return resourceManagerMap
+	return m.rootResource
 }
 
 /*
-JSON화
+잠금을 확인하며 경로에 리소스 생성
+새 리소스가 추가될 상위 리소스가 잠겨있으면 해당 잠금 토큰이 있어야 함
+isStrict가 true이면 없는 상위 폴더를 생성하지 않고 ErrParentNotFound 반환
+  - @param {string} creator 생성한 유저
+  - @return {*ResourceObject} 생성한 리소스 객체의 포인터, 실패시 nil
+  - @return {error} 실패 원인
 */
-func (m ResourceManager) ToJson() (string, error) {
-	jsonData, err := json.Marshal(m.ToMap())
+func (m *ResourceManager) CreateResourceWithLockTokens(path string, isDirectory bool, isStrict bool, creator string, creatorGroup string, lockTokens []string) (*ResourceObject, error) {
+	m, unlock, err := m.lockTree()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
 
+	if !util.IsValidPath(path) || path == "/" {
+		return nil, ErrInvalidPath
+	}
+	if m.GetResourceObject(path) != nil {
+		return nil, ErrAlreadyExists
+	}
+
+	parentResource := m.GetNearestExistingParent(path)
+	if isStrict {
+		parentPath, _ := util.GetParentDirectory(path)
+		if parentResource.GetPath() != parentPath {
+			return nil, ErrParentNotFound
+		}
+	}
+	if !parentResource.IsDirectory() {
+		return nil, ErrNotDirectory
+	}
+	if !parentResource.CanModify(lockTokens) {
+		return nil, ErrLocked
+	}
+
+	// 새로 생성할 상위 폴더를 포함한 리소스 개수로 용량 확인
+	parentDepth := strings.Count(parentResource.GetPath(), "/")
+	if parentResource.GetPath() == "/" {
+		parentDepth = 0
+	}
+	err = m.checkQuota(quotaChange{
+		parent:     parentResource,
+		owner:      creator,
+		ownerGroup: creatorGroup,
+		nodes:      int64(strings.Count(path, "/") - parentDepth),
+	})
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return string(jsonData), err
+	success, resource := m.createResource(path, isDirectory, creator, creatorGroup)
+	if !success {
+		return nil, ErrInvalidPath
+	}
+	parentResource.touch(creator)
+	return resource, nil
 }
 
 /*
-ResourceManager 생성자 함수
+잠금을 확인하며 경로의 리소스 삭제
+부모 리소스와 삭제할 리소스의 모든 하위 리소스가 잠겨있으면 해당 잠금 토큰이 있어야 함
+  - @param {string} modifier 삭제한 유저
 */
-func NewResourceManager() *ResourceManager {
-	m := &ResourceManager{
-		rootResource: NewResourceObject(ResourceConstructorParam{
-			Name:               "",
-			Path:               "/",
-			IsDirectory:        true,
-			UserPermissionMap:  map[string][]string{},
-			GroupPermissionMap: map[string][]string{},
-		}),
+func (m *ResourceManager) DeleteResourceWithLockTokens(path string, modifier string, lockTokens []string) error {
+	m, unlock, err := m.lockTree()
+	if err != nil {
+		return err
 	}
-	return m
+	defer unlock()
+
+	if !util.IsValidPath(path) || path == "/" {
+		return ErrInvalidPath
+	}
+
+	resource := m.GetResourceObject(path)
+	if resource == nil {
+		return ErrNotFound
+	}
+	parentResource := m.GetNearestExistingParent(path)
+	if !parentResource.CanModify(lockTokens) || !resource.CanModifyRecursive(lockTokens) {
+		return ErrLocked
+	}
+
+	if m.trashPolicy.IsEnabled {
+		m.moveToTrash(resource, modifier)
+	} else if !m.deleteResource(path, modifier) {
+		return ErrNotFound
+	}
+	parentResource.touch(modifier)
+	return nil
 }
 
 /*
-JSON으로 ResourceManager 생성
+리소스를 부모 폴더에서 떼어내 휴지통으로 옮김
+리소스에 걸린 잠금은 해제
 */
-func FromJsonResourceManager(jsonData string) *ResourceManager {
-	var resourceManagerMap map[string]any
-	json.Unmarshal([]byte(jsonData), &resourceManagerMap)
+func (m *ResourceManager) moveToTrash(resource *ResourceObject, deleter string) {
+	m.RemoveExpiredTrashItems()
+
+	item := TrashItem{
+		id:           util.GenerateRandomString(16),
+		originalPath: resource.GetPath(),
+		resource:     resource,
+		deleter:      deleter,
+		deletedAt:    time.Now(),
+	}
+	resource.parent.detachChild(resource.name)
+	m.unindexTree(resource)
+	for _, lock := range resource.GetLocks() {
+		resource.removeLock(lock.token)
+	}
+	m.trashItems = append(m.trashItems, item)
+	m.emitEvent(EVENT_TYPE_DELETED, resource, item.originalPath, "", deleter)
+}
+
+/*
+휴지통 항목 목록을 최근에 삭제한 순서로 반환
+보관 기간이 지난 항목은 다음 쓰기 작업에서 삭제되므로 제외
+  - @param {string} deleter 해당 유저가 삭제한 항목만 반환, 빈 문자열이면 전체
+*/
+func (m *ResourceManager) GetTrashItems(deleter string) []TrashItem {
+	m, unlock := m.rlockTree()
+	defer unlock()
+
+	now := time.Now()
+	items := []TrashItem{}
+	for i := len(m.trashItems) - 1; i >= 0; i-- {
+		item := m.trashItems[i]
+		if (deleter == "" || item.deleter == deleter) && !item.IsExpired(m.trashPolicy, now) {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+/*
+id에 해당하는 휴지통 항목 반환
+보관 기간이 지난 항목은 없는 것으로 처리
+*/
+func (m *ResourceManager) GetTrashItem(id string) (TrashItem, bool) {
+	m, unlock := m.rlockTree()
+	defer unlock()
+
+	for _, item := range m.trashItems {
+		if item.id == id && !item.IsExpired(m.trashPolicy, time.Now()) {
+			return item, true
+		}
+	}
+	return TrashItem{}, false
+}
+
+/*
+휴지통 항목을 원래 경로 또는 지정한 경로로 복원
+경로에 리소스가 있으면 isOverwrite가 true일 때만 덮어쓰며, 덮어쓴 리소스는 휴지통으로 옮김
+  - @param {string} destinationPath 복원할 경로, 빈 문자열이면 원래 경로
+  - @return {bool} 경로의 리소스를 덮어썼는지 여부
+*/
+func (m *ResourceManager) RestoreTrashItemWithLockTokens(id string, destinationPath string, isOverwrite bool, modifier string, lockTokens []string) (bool, error) {
+	m, unlock, err := m.lockTree()
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+
+	m.RemoveExpiredTrashItems()
+	item, ok := m.GetTrashItem(id)
+	if !ok {
+		return false, ErrTrashItemNotFound
+	}
+	if destinationPath == "" {
+		destinationPath = item.originalPath
+	}
+	if !util.IsValidPath(destinationPath) || destinationPath == "/" {
+		return false, ErrInvalidPath
+	}
+
+	destinationParentPath, _ := util.GetParentDirectory(destinationPath)
+	destinationParentResource := m.GetResourceObject(destinationParentPath)
+	if destinationParentResource == nil {
+		return false, ErrParentNotFound
+	}
+	if !destinationParentResource.IsDirectory() {
+		return false, ErrNotDirectory
+	}
+
+	destinationResource := m.GetResourceObject(destinationPath)
+	if destinationResource != nil && !isOverwrite {
+		return false, ErrAlreadyExists
+	}
+
+	// 잠금 확인
+	if !destinationParentResource.CanModify(lockTokens) ||
+		(destinationResource != nil && !destinationResource.CanModifyRecursive(lockTokens)) {
+		return false, ErrLocked
+	}
+
+	// 덮어쓸 리소스를 휴지통으로 옮긴 후 복원
+	if destinationResource != nil {
+		m.moveToTrash(destinationResource, modifier)
+	}
+	m.removeTrashItem(id)
+	names := strings.Split(destinationPath, "/")
+	destinationParentResource.AttachChild(names[len(names)-1], item.resource)
+	m.indexTree(item.resource)
+	m.emitEvent(EVENT_TYPE_CREATED, item.resource, destinationPath, "", modifier)
+	destinationParentResource.touch(modifier)
+	return destinationResource != nil, nil
+}
+
+/*
+휴지통 항목을 목록에서 제거
+*/
+func (m *ResourceManager) removeTrashItem(id string) bool {
+	for i, item := range m.trashItems {
+		if item.id == id {
+			m.trashItems = append(m.trashItems[:i], m.trashItems[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+/*
+휴지통 항목을 영구 삭제
+  - @return {bool} 삭제 성공 여부
+*/
+func (m *ResourceManager) PurgeTrashItem(id string) bool {
+	m, unlock, err := m.lockTree()
+	if err != nil {
+		return false
+	}
+	defer unlock()
+
+	m.RemoveExpiredTrashItems()
+	if !m.removeTrashItem(id) {
+		return false
+	}
+	m.CollectGarbage()
+	return true
+}
+
+/*
+휴지통 항목을 모두 영구 삭제
+  - @param {string} deleter 해당 유저가 삭제한 항목만 삭제, 빈 문자열이면 전체
+  - @return {[]TrashItem} 삭제한 항목 목록
+*/
+func (m *ResourceManager) PurgeTrash(deleter string) []TrashItem {
+	m, unlock, err := m.lockTree()
+	if err != nil {
+		return []TrashItem{}
+	}
+	defer unlock()
+
+	// 보관 기간이 지난 항목은 이미 삭제된 것으로 보고 반환하지 않음
+	m.RemoveExpiredTrashItems()
+
+	purgedItems := []TrashItem{}
+	trashItems := []TrashItem{}
+	for _, item := range m.trashItems {
+		if deleter == "" || item.deleter == deleter {
+			purgedItems = append(purgedItems, item)
+		} else {
+			trashItems = append(trashItems, item)
+		}
+	}
+	m.trashItems = trashItems
+
+	if len(purgedItems) > 0 {
+		m.CollectGarbage()
+	}
+	return purgedItems
+}
+
+/*
+보관 기간이 지난 휴지통 항목을 영구 삭제
+  - @return {int} 삭제한 항목 개수
+*/
+func (m *ResourceManager) RemoveExpiredTrashItems() int {
+	m, unlock, err := m.lockTree()
+	if err != nil {
+		return 0
+	}
+	defer unlock()
+
+	now := time.Now()
+	trashItems := []TrashItem{}
+	for _, item := range m.trashItems {
+		if !item.IsExpired(m.trashPolicy, now) {
+			trashItems = append(trashItems, item)
+		}
+	}
+
+	count := len(m.trashItems) - len(trashItems)
+	if count > 0 {
+		m.trashItems = trashItems
+		m.CollectGarbage()
+	}
+	return count
+}
+
+/*
+휴지통 보관 정책 반환
+*/
+func (m *ResourceManager) GetTrashPolicy() TrashPolicy {
+	m, unlock := m.rlockTree()
+	defer unlock()
+	return m.trashPolicy
+}
+
+/*
+휴지통 보관 정책 변경
+변경한 보관 기간은 바로 적용
+*/
+func (m *ResourceManager) SetTrashPolicy(trashPolicy TrashPolicy) {
+	m, unlock, err := m.lockTree()
+	if err != nil {
+		return
+	}
+	defer unlock()
+
+	m.trashPolicy = trashPolicy
+	m.RemoveExpiredTrashItems()
+}
+
+/*
+잠금을 확인하며 리소스 이동
+원래 부모 폴더, 옮길 리소스와 모든 하위 리소스, 새 부모 폴더, 덮어쓸 리소스와 모든 하위 리소스가
+잠겨있으면 해당 잠금 토큰이 있어야 함
+옮긴 리소스에 걸린 잠금은 해제됨
+  - @param {bool} isOverwrite 목적지에 리소스가 있으면 삭제하고 옮길지 여부
+  - @param {string} modifier 옮긴 유저
+  - @return {bool} 목적지의 리소스를 덮어썼는지 여부
+  - @return {error} 실패 원인
+*/
+func (m *ResourceManager) MoveResourceWithLockTokens(sourcePath string, destinationPath string, isOverwrite bool, modifier string, lockTokens []string) (bool, error) {
+	m, unlock, err := m.lockTree()
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+
+	if !util.IsValidPath(sourcePath) || sourcePath == "/" || !util.IsValidPath(destinationPath) || destinationPath == "/" {
+		return false, ErrInvalidPath
+	}
+	// 자기 자신이나 하위 리소스로 옮기거나, 상위 폴더를 덮어쓸 수 없음
+	if sourcePath == destinationPath ||
+		strings.HasPrefix(destinationPath, sourcePath+"/") ||
+		strings.HasPrefix(sourcePath, destinationPath+"/") {
+		return false, ErrInvalidPath
+	}
+
+	resource := m.GetResourceObject(sourcePath)
+	if resource == nil {
+		return false, ErrNotFound
+	}
+	sourceParentResource := resource.parent
+
+	destinationParentPath, _ := util.GetParentDirectory(destinationPath)
+	destinationParentResource := m.GetResourceObject(destinationParentPath)
+	if destinationParentResource == nil {
+		return false, ErrParentNotFound
+	}
+	if !destinationParentResource.IsDirectory() {
+		return false, ErrNotDirectory
+	}
+
+	destinationResource := m.GetResourceObject(destinationPath)
+	if destinationResource != nil && !isOverwrite {
+		return false, ErrAlreadyExists
+	}
+
+	// 잠금 확인
+	if !sourceParentResource.CanModify(lockTokens) ||
+		!resource.CanModifyRecursive(lockTokens) ||
+		!destinationParentResource.CanModify(lockTokens) ||
+		(destinationResource != nil && !destinationResource.CanModifyRecursive(lockTokens)) {
+		return false, ErrLocked
+	}
+
+	// 옮길 폴더의 용량 확인, 소유자는 변하지 않음
+	err = m.checkQuota(quotaChange{
+		parent:  destinationParentResource,
+		bytes:   resource.size,
+		nodes:   resource.countNodes(),
+		removed: destinationResource,
+		moved:   resource,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	// 덮어쓸 리소스 삭제 후 이동
+	if destinationResource != nil && m.trashPolicy.IsEnabled {
+		m.moveToTrash(destinationResource, modifier)
+	} else if destinationResource != nil {
+		m.unindexTree(destinationResource)
+		m.emitEvent(EVENT_TYPE_DELETED, destinationResource, destinationPath, "", modifier)
+		destinationParentResource.DeleteChild(destinationResource.name)
+		m.CollectGarbage()
+	}
+	names := strings.Split(destinationPath, "/")
+	destinationParentResource.AttachChild(names[len(names)-1], resource)
+	m.emitEvent(EVENT_TYPE_MOVED, resource, sourcePath, destinationPath, modifier)
+
+	resource.touch(modifier)
+	sourceParentResource.touch(modifier)
+	destinationParentResource.touch(modifier)
+	return destinationResource != nil, nil
+}
+
+/*
+잠금을 확인하며 리소스 객체 반환
+*/
+func (m *ResourceManager) getModifiableResourceObject(path string, lockTokens []string) (*ResourceObject, error) {
+	if !util.IsValidPath(path) {
+		return nil, ErrInvalidPath
+	}
+
+	resource := m.GetResourceObject(path)
+	if resource == nil {
+		return nil, ErrNotFound
+	}
+	if !resource.CanModify(lockTokens) {
+		return nil, ErrLocked
+	}
+	return resource, nil
+}
+
+/*
+잠금을 확인하며 경로에 특정 유저의 권한 추가
+  - @param {string} modifier 권한을 변경한 유저
+*/
+func (m *ResourceManager) AddUserPermissionWithLockTokens(path string, username string, permission string, modifier string, lockTokens []string) ([]string, error) {
+	m, unlock, err := m.lockTree()
+	if err != nil {
+		return []string{}, err
+	}
+	defer unlock()
+
+	resource, err := m.getModifiableResourceObject(path, lockTokens)
+	if err != nil {
+		return []string{}, err
+	}
+
+	permissions := resource.AddUserPermission(username, permission)
+	m.permissionIndex.updateUser(username, resource)
+	m.logAclChange("AddUserPermission", resource.GetPath(), modifier, PRINCIPAL_TYPE_USER, username, permission)
+	m.emitEvent(EVENT_TYPE_ACL_CHANGED, resource, resource.GetPath(), "", modifier)
+	resource.touch(modifier)
+	return permissions, nil
+}
+
+/*
+잠금을 확인하며 경로에 특정 그룹의 권한 추가
+  - @param {string} modifier 권한을 변경한 유저
+*/
+func (m *ResourceManager) AddGroupPermissionWithLockTokens(path string, groupname string, permission string, modifier string, lockTokens []string) ([]string, error) {
+	m, unlock, err := m.lockTree()
+	if err != nil {
+		return []string{}, err
+	}
+	defer unlock()
+
+	resource, err := m.getModifiableResourceObject(path, lockTokens)
+	if err != nil {
+		return []string{}, err
+	}
+
+	permissions := resource.AddGroupPermission(groupname, permission)
+	m.permissionIndex.updateGroup(groupname, resource)
+	m.logAclChange("AddGroupPermission", resource.GetPath(), modifier, PRINCIPAL_TYPE_GROUP, groupname, permission)
+	m.emitEvent(EVENT_TYPE_ACL_CHANGED, resource, resource.GetPath(), "", modifier)
+	resource.touch(modifier)
+	return permissions, nil
+}
+
+/*
+잠금을 확인하며 경로에 특정 유저의 권한 삭제
+  - @param {string} modifier 권한을 변경한 유저
+*/
+func (m *ResourceManager) DeleteUserPermissionWithLockTokens(path string, username string, permission string, modifier string, lockTokens []string) error {
+	m, unlock, err := m.lockTree()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	resource, err := m.getModifiableResourceObject(path, lockTokens)
+	if err != nil {
+		return err
+	}
+
+	resource.DeleteUserPermission(username, permission)
+	m.permissionIndex.updateUser(username, resource)
+	m.logAclChange("DeleteUserPermission", resource.GetPath(), modifier, PRINCIPAL_TYPE_USER, username, permission)
+	m.emitEvent(EVENT_TYPE_ACL_CHANGED, resource, resource.GetPath(), "", modifier)
+	resource.touch(modifier)
+	return nil
+}
+
+/*
+잠금을 확인하며 경로에 특정 그룹의 권한 삭제
+  - @param {string} modifier 권한을 변경한 유저
+*/
+func (m *ResourceManager) DeleteGroupPermissionWithLockTokens(path string, groupname string, permission string, modifier string, lockTokens []string) error {
+	m, unlock, err := m.lockTree()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	resource, err := m.getModifiableResourceObject(path, lockTokens)
+	if err != nil {
+		return err
+	}
+
+	resource.DeleteGroupPermission(groupname, permission)
+	m.permissionIndex.updateGroup(groupname, resource)
+	m.logAclChange("DeleteGroupPermission", resource.GetPath(), modifier, PRINCIPAL_TYPE_GROUP, groupname, permission)
+	m.emitEvent(EVENT_TYPE_ACL_CHANGED, resource, resource.GetPath(), "", modifier)
+	resource.touch(modifier)
+	return nil
+}
+
+/*
+잠금을 확인하며 경로의 리소스에 사용자 정의 속성 설정, 삭제를 순서대로 적용
+  - @param {string} modifier 속성을 변경한 유저
+*/
+func (m *ResourceManager) PatchPropertiesWithLockTokens(path string, patches []PropertyPatch, modifier string, lockTokens []string) error {
+	m, unlock, err := m.lockTree()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	resource, err := m.getModifiableResourceObject(path, lockTokens)
+	if err != nil {
+		return err
+	}
+
+	resource.PatchProperties(patches)
+	resource.touch(modifier)
+	return nil
+}
+
+/*
+잠금을 확인하며 파일 내용 변경
+내용은 저장소에 저장하고 리소스에는 내용의 해시를 기록
+내용을 받는 동안에는 트리를 잠그지 않으며, 받은 후 잠금과 용량을 다시 확인
+  - @param {string} modifier 내용을 변경한 유저
+*/
+func (m *ResourceManager) WriteContentWithLockTokens(path string, reader io.Reader, modifier string, lockTokens []string) error {
+	_, err := m.putContent(path, reader, false, modifier, "", lockTokens)
+	return err
+}
+
+/*
+잠금을 확인하며 경로에 파일을 생성하거나 기존 파일의 내용을 변경
+내용을 저장소에 모두 저장한 후 파일 생성과 내용 연결을 한 번에 처리하므로, 다른 요청은 내용이 없는 파일을 볼 수 없고 실패해도 생성한 파일이 남지 않음
+파일을 생성할 때는 상위 폴더를 생성하지 않으며, 상위 폴더가 잠겨있으면 해당 잠금 토큰이 있어야 함
+  - @param {string} creator 파일을 생성하거나 내용을 변경한 유저
+  - @return {bool} 파일을 새로 생성했는지 여부
+*/
+func (m *ResourceManager) PutContentWithLockTokens(path string, reader io.Reader, creator string, creatorGroup string, lockTokens []string) (bool, error) {
+	return m.putContent(path, reader, true, creator, creatorGroup, lockTokens)
+}
+
+/*
+내용을 저장소에 저장한 후 경로의 파일에 연결
+파일이 없으면 isCreatable이 true일 때만 생성
+*/
+func (m *ResourceManager) putContent(path string, reader io.Reader, isCreatable bool, creator string, creatorGroup string, lockTokens []string) (bool, error) {
+	// 쓸 수 없는 경로이면 내용을 받지 않음
+	err := m.checkContentTarget(path, isCreatable, lockTokens)
+	if err != nil {
+		return false, err
+	}
+	contentHash, size, err := m.putPendingContent(reader)
+	if err != nil {
+		return false, err
+	}
+
+	m, unlock, err := m.lockTree()
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+	m.pendingWrites.Add(-1)
+
+	// 내용을 받는 동안 트리가 바뀌었을 수 있으므로 다시 확인하고, 실패하면 저장한 내용은 CollectGarbage가 삭제
+	isCreated, err := m.attachContent(path, contentHash, size, isCreatable, creator, creatorGroup, lockTokens)
+	m.CollectGarbage()
+	return isCreated, err
+}
+
+/*
+잠금을 확인하며 경로에 내용을 쓸 수 있는지 확인
+*/
+func (m *ResourceManager) checkContentTarget(path string, isCreatable bool, lockTokens []string) error {
+	m, unlock := m.rlockTree()
+	defer unlock()
+
+	_, _, err := m.getContentTarget(path, isCreatable, lockTokens)
+	return err
+}
+
+/*
+내용을 쓸 파일 반환
+  - @return {*ResourceObject} 내용을 쓸 파일, 없으면 nil
+  - @return {*ResourceObject} 파일이 없고 isCreatable이 true이면 파일을 생성할 부모 폴더, 그 외에는 nil
+*/
+func (m *ResourceManager) getContentTarget(path string, isCreatable bool, lockTokens []string) (*ResourceObject, *ResourceObject, error) {
+	if !util.IsValidPath(path) {
+		return nil, nil, ErrInvalidPath
+	}
+
+	resource := m.GetResourceObject(path)
+	if resource != nil {
+		if !resource.CanModify(lockTokens) {
+			return nil, nil, ErrLocked
+		}
+		if !resource.IsFile() {
+			return nil, nil, ErrNotFile
+		}
+		return resource, nil, nil
+	}
+	if !isCreatable {
+		return nil, nil, ErrNotFound
+	}
+
+	parentPath, _ := util.GetParentDirectory(path)
+	parentResource := m.GetResourceObject(parentPath)
+	if parentResource == nil {
+		return nil, nil, ErrParentNotFound
+	}
+	if !parentResource.IsDirectory() {
+		return nil, nil, ErrNotDirectory
+	}
+	if !parentResource.CanModify(lockTokens) {
+		return nil, nil, ErrLocked
+	}
+	return nil, parentResource, nil
+}
+
+/*
+저장소에 저장한 내용을 경로의 파일에 연결
+파일이 없으면 생성하며, 트리 쓰기 잠금을 잡은 상태에서 호출
+*/
+func (m *ResourceManager) attachContent(path string, contentHash string, size int64, isCreatable bool, creator string, creatorGroup string, lockTokens []string) (bool, error) {
+	resource, parentResource, err := m.getContentTarget(path, isCreatable, lockTokens)
+	if err != nil {
+		return false, err
+	}
+
+	// 용량을 넘으면 생성하지 않음
+	isCreated := resource == nil
+	if isCreated {
+		err = m.checkQuota(quotaChange{
+			parent:     parentResource,
+			owner:      creator,
+			ownerGroup: creatorGroup,
+			bytes:      size,
+			nodes:      1,
+		})
+	} else {
+		err = m.checkQuota(quotaChange{
+			parent:     resource.parent,
+			owner:      resource.creator,
+			ownerGroup: resource.creatorGroup,
+			bytes:      size - resource.size,
+		})
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if isCreated {
+		var success bool
+		success, resource = m.createResource(path, false, creator, creatorGroup)
+		if !success {
+			return false, ErrInvalidPath
+		}
+		parentResource.touch(creator)
+	}
+	m.ownerUsageIndex.addBytes(resource, size-resource.size)
+	resource.UpdateContent(contentHash, size, creator)
+	resource.pruneVersions(m.versionPolicy, time.Now())
+	m.emitEvent(EVENT_TYPE_CONTENT_CHANGED, resource, resource.GetPath(), "", creator)
+	return isCreated, nil
+}
+
+/*
+트리를 잠그지 않고 내용을 저장소에 저장
+성공하면 pendingWrites가 1 증가한 상태로 반환하므로, 트리 쓰기 잠금을 잡은 후 내용을 리소스에 연결하기 전에 1 감소시켜야 함
+트리 쓰기 잠금을 잡은 상태에서 호출하면 잠금을 유지한 채 저장
+*/
+func (m *ResourceManager) putPendingContent(reader io.Reader) (string, int64, error) {
+	contentStore := m.GetContentStore()
+	m.pendingWrites.Add(1)
+	contentHash, size, err := contentStore.Put(reader)
+	if err != nil {
+		m.pendingWrites.Add(-1)
+		return "", 0, err
+	}
+	return contentHash, size, nil
+}
+
+/*
+리소스와 하위 리소스를 복사
+복사한 리소스는 새로 생성한 리소스로 취급하여 복사한 유저가 소유하고 새 부모 폴더의 권한을 상속
+원래 권한이 사라지므로 복사한 유저 또는 그룹이 "read" 권한을 가지지 않은 리소스가 하나라도 포함되면 ErrPermissionDenied
+경로에 리소스가 있으면 isOverwrite가 true일 때만 덮어쓰며, 덮어쓴 리소스는 휴지통을 사용하면 휴지통으로 옮김
+  - @param {bool} isDepthInfinity false이면 폴더만 복사하고 하위 리소스는 복사하지 않음
+  - @return {bool} 경로의 리소스를 덮어썼는지 여부
+*/
+func (m *ResourceManager) CopyResourceWithLockTokens(sourcePath string, destinationPath string, isOverwrite bool, isDepthInfinity bool, modifier string, modifierGroup string, lockTokens []string) (bool, error) {
+	m, unlock, err := m.lockTree()
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+
+	if !util.IsValidPath(sourcePath) || !util.IsValidPath(destinationPath) || destinationPath == "/" {
+		return false, ErrInvalidPath
+	}
+	// 자기 자신이나 하위 리소스로 복사하거나, 상위 폴더를 덮어쓸 수 없음
+	if sourcePath == destinationPath ||
+		(isDepthInfinity && (sourcePath == "/" || strings.HasPrefix(destinationPath, sourcePath+"/"))) ||
+		strings.HasPrefix(sourcePath, destinationPath+"/") {
+		return false, ErrInvalidPath
+	}
+
+	resource := m.GetResourceObject(sourcePath)
+	if resource == nil {
+		return false, ErrNotFound
+	}
+	if (isDepthInfinity && !resource.CheckPermissionRecursive(modifier, modifierGroup, "read")) ||
+		!resource.CheckPermission(modifier, modifierGroup, "read") {
+		return false, ErrPermissionDenied
+	}
+
+	destinationParentPath, _ := util.GetParentDirectory(destinationPath)
+	destinationParentResource := m.GetResourceObject(destinationParentPath)
+	if destinationParentResource == nil {
+		return false, ErrParentNotFound
+	}
+	if !destinationParentResource.IsDirectory() {
+		return false, ErrNotDirectory
+	}
+
+	destinationResource := m.GetResourceObject(destinationPath)
+	if destinationResource != nil && !isOverwrite {
+		return false, ErrAlreadyExists
+	}
+
+	// 잠금 확인
+	if !destinationParentResource.CanModify(lockTokens) ||
+		(destinationResource != nil && !destinationResource.CanModifyRecursive(lockTokens)) {
+		return false, ErrLocked
+	}
+
+	copiedResource := resource.clone()
+	if !isDepthInfinity {
+		copiedResource.childrenMap = map[string](*ResourceObject){}
+		if copiedResource.IsDirectory() {
+			copiedResource.size = 0
+		}
+	}
+
+	err = m.checkQuota(quotaChange{
+		parent:     destinationParentResource,
+		owner:      modifier,
+		ownerGroup: modifierGroup,
+		bytes:      copiedResource.size,
+		nodes:      copiedResource.countNodes(),
+		removed:    destinationResource,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	// 덮어쓸 리소스 삭제 후 복사
+	if destinationResource != nil && m.trashPolicy.IsEnabled {
+		m.moveToTrash(destinationResource, modifier)
+	} else if destinationResource != nil {
+		m.unindexTree(destinationResource)
+		m.emitEvent(EVENT_TYPE_DELETED, destinationResource, destinationPath, "", modifier)
+		destinationParentResource.DeleteChild(destinationResource.name)
+		m.CollectGarbage()
+	}
+	copiedResource.resetAsCopy(destinationParentResource, modifier, modifierGroup, time.Now())
+	names := strings.Split(destinationPath, "/")
+	destinationParentResource.AttachChild(names[len(names)-1], copiedResource)
+	m.indexTree(copiedResource)
+	m.emitEvent(EVENT_TYPE_CREATED, copiedResource, destinationPath, "", modifier)
+	destinationParentResource.touch(modifier)
+	return destinationResource != nil, nil
+}
+
+/*
+파일의 이전 버전을 현재 내용으로 복원
+현재 내용은 이전 버전으로 보관되고 복원한 내용은 새 버전 번호를 받음
+보관되지 않은 버전이면 ErrVersionNotFound
+*/
+func (m *ResourceManager) RestoreVersionWithLockTokens(path string, number int64, modifier string, lockTokens []string) error {
+	m, unlock, err := m.lockTree()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	resource, err := m.getModifiableResourceObject(path, lockTokens)
+	if err != nil {
+		return err
+	}
+	if !resource.IsFile() {
+		return ErrNotFile
+	}
+
+	version, ok := resource.GetVersionByNumber(number)
+	if !ok {
+		return ErrVersionNotFound
+	}
+	err = m.checkQuota(quotaChange{
+		parent:     resource.parent,
+		owner:      resource.creator,
+		ownerGroup: resource.creatorGroup,
+		bytes:      version.GetSize() - resource.size,
+	})
+	if err != nil {
+		return err
+	}
+	m.ownerUsageIndex.addBytes(resource, version.GetSize()-resource.size)
+	resource.UpdateContent(version.GetContentHash(), version.GetSize(), modifier)
+	resource.pruneVersions(m.versionPolicy, time.Now())
+	m.CollectGarbage()
+	m.emitEvent(EVENT_TYPE_CONTENT_CHANGED, resource, resource.GetPath(), "", modifier)
+	return nil
+}
+
+/*
+파일의 특정 버전 내용 반환
+현재 내용의 번호도 사용할 수 있음
+*/
+func (m *ResourceManager) OpenVersionContent(path string, number int64) (io.ReadSeekCloser, error) {
+	m, unlock := m.rlockTree()
+	defer unlock()
+
+	resource := m.GetResourceObject(path)
+	if resource == nil {
+		return nil, ErrNotFound
+	}
+	if !resource.IsFile() {
+		return nil, ErrNotFile
+	}
+
+	version, ok := resource.GetVersionByNumber(number)
+	if !ok {
+		return nil, ErrVersionNotFound
+	}
+	return m.contentStore.Open(version.GetContentHash())
+}
+
+/*
+이전 버전 보관 정책 반환
+*/
+func (m *ResourceManager) GetVersionPolicy() VersionPolicy {
+	m, unlock := m.rlockTree()
+	defer unlock()
+	return m.versionPolicy
+}
+
+/*
+이전 버전 보관 정책 변경
+변경한 정책은 바로 모든 파일에 적용
+*/
+func (m *ResourceManager) SetVersionPolicy(versionPolicy VersionPolicy) {
+	m, unlock, err := m.lockTree()
+	if err != nil {
+		return
+	}
+	defer unlock()
+
+	m.versionPolicy = versionPolicy
+	m.PruneVersions()
+}
+
+/*
+모든 파일에서 보관 정책을 벗어난 이전 버전을 제거하고 참조되지 않는 내용 삭제
+기간 제한은 파일에 내용을 쓸 때만 적용되므로 주기적으로 호출
+  - @return {int} 제거한 버전 개수
+  - @return {int} 삭제한 내용 개수
+*/
+func (m *ResourceManager) PruneVersions() (int, int) {
+	m, unlock, err := m.lockTree()
+	if err != nil {
+		return 0, 0
+	}
+	defer unlock()
+
+	prunedCount := m.rootResource.pruneVersions(m.versionPolicy, time.Now())
+	return prunedCount, m.CollectGarbage()
+}
+
+/*
+현재 리소스 트리 전체의 스냅샷 생성
+*/
+func (m *ResourceManager) CreateSnapshot(label string) (ResourceSnapshot, error) {
+	return m.CreateSnapshotWithCreator(label, "")
+}
+
+/*
+현재 리소스 트리 전체의 스냅샷을 만든 유저와 함께 생성
+이름은 경로에 사용되므로 '/'를 포함할 수 없으며, 같은 이름의 스냅샷이 있으면 ErrAlreadyExists
+*/
+func (m *ResourceManager) CreateSnapshotWithCreator(label string, creator string) (ResourceSnapshot, error) {
+	m, unlock, err := m.lockTree()
+	if err != nil {
+		return ResourceSnapshot{}, err
+	}
+	defer unlock()
+
+	if !util.IsValidPath("/"+label) || strings.Contains(label, "/") {
+		return ResourceSnapshot{}, ErrInvalidPath
+	}
+	if _, ok := m.GetSnapshot(label); ok {
+		return ResourceSnapshot{}, ErrAlreadyExists
+	}
+
+	snapshot := ResourceSnapshot{
+		label:     label,
+		root:      m.rootResource.clone(),
+		creator:   creator,
+		createdAt: time.Now(),
+	}
+	m.snapshots = append(m.snapshots, snapshot)
+	return snapshot, nil
+}
+
+/*
+스냅샷 목록을 만든 순서로 반환
+*/
+func (m *ResourceManager) GetSnapshots() []ResourceSnapshot {
+	m, unlock := m.rlockTree()
+	defer unlock()
+	return util.CloneSlice(m.snapshots)
+}
+
+/*
+이름에 해당하는 스냅샷 반환
+*/
+func (m *ResourceManager) GetSnapshot(label string) (ResourceSnapshot, bool) {
+	m, unlock := m.rlockTree()
+	defer unlock()
+
+	for _, snapshot := range m.snapshots {
+		if snapshot.label == label {
+			return snapshot, true
+		}
+	}
+	return ResourceSnapshot{}, false
+}
+
+/*
+스냅샷 삭제
+스냅샷만 참조하던 내용도 삭제
+  - @return {bool} 삭제 성공 여부
+*/
+func (m *ResourceManager) DeleteSnapshot(label string) bool {
+	m, unlock, err := m.lockTree()
+	if err != nil {
+		return false
+	}
+	defer unlock()
+
+	for i, snapshot := range m.snapshots {
+		if snapshot.label == label {
+			m.snapshots = append(m.snapshots[:i], m.snapshots[i+1:]...)
+			m.CollectGarbage()
+			return true
+		}
+	}
+	return false
+}
+
+/*
+스냅샷에 있는 파일의 내용 반환
+*/
+func (m *ResourceManager) OpenSnapshotContent(label string, path string) (io.ReadSeekCloser, error) {
+	m, unlock := m.rlockTree()
+	defer unlock()
+
+	snapshot, ok := m.GetSnapshot(label)
+	if !ok {
+		return nil, ErrSnapshotNotFound
+	}
+	resource := snapshot.GetResourceObject(path)
+	if resource == nil {
+		return nil, ErrNotFound
+	}
+	if !resource.IsFile() {
+		return nil, ErrNotFile
+	}
+
+	return m.contentStore.Open(resource.GetContentHash())
+}
+
+/*
+스냅샷의 리소스와 하위 리소스를 현재 트리로 복원
+경로에 리소스가 있으면 isOverwrite가 true일 때만 덮어쓰며, 덮어쓴 리소스는 휴지통을 사용하면 휴지통으로 옮김
+루트 폴더는 복원할 수 없으므로 하위 리소스를 각각 복원
+  - @param {string} path 스냅샷에서 복원할 리소스의 경로
+  - @param {string} destinationPath 복원할 경로, 빈 문자열이면 path와 같은 경로
+  - @return {bool} 경로의 리소스를 덮어썼는지 여부
+*/
+func (m *ResourceManager) RestoreSnapshotWithLockTokens(label string, path string, destinationPath string, isOverwrite bool, modifier string, lockTokens []string) (bool, error) {
+	m, unlock, err := m.lockTree()
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+
+	snapshot, ok := m.GetSnapshot(label)
+	if !ok {
+		return false, ErrSnapshotNotFound
+	}
+	resource := snapshot.GetResourceObject(path)
+	if resource == nil {
+		return false, ErrNotFound
+	}
+	if destinationPath == "" {
+		destinationPath = path
+	}
+	if !util.IsValidPath(destinationPath) || destinationPath == "/" {
+		return false, ErrInvalidPath
+	}
+
+	destinationParentPath, _ := util.GetParentDirectory(destinationPath)
+	destinationParentResource := m.GetResourceObject(destinationParentPath)
+	if destinationParentResource == nil {
+		return false, ErrParentNotFound
+	}
+	if !destinationParentResource.IsDirectory() {
+		return false, ErrNotDirectory
+	}
+
+	destinationResource := m.GetResourceObject(destinationPath)
+	if destinationResource != nil && !isOverwrite {
+		return false, ErrAlreadyExists
+	}
+
+	// 잠금 확인
+	if !destinationParentResource.CanModify(lockTokens) ||
+		(destinationResource != nil && !destinationResource.CanModifyRecursive(lockTokens)) {
+		return false, ErrLocked
+	}
+
+	// 복원할 폴더의 용량 확인, 복원한 리소스는 원래 소유자가 소유
+	err = m.checkQuota(quotaChange{
+		parent:  destinationParentResource,
+		bytes:   resource.size,
+		nodes:   resource.countNodes(),
+		removed: destinationResource,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	// 덮어쓸 리소스 삭제 후 복원
+	if destinationResource != nil && m.trashPolicy.IsEnabled {
+		m.moveToTrash(destinationResource, modifier)
+	} else if destinationResource != nil {
+		m.unindexTree(destinationResource)
+		m.emitEvent(EVENT_TYPE_DELETED, destinationResource, destinationPath, "", modifier)
+		destinationParentResource.DeleteChild(destinationResource.name)
+		m.CollectGarbage()
+	}
+	restoredResource := resource.clone()
+	names := strings.Split(destinationPath, "/")
+	destinationParentResource.AttachChild(names[len(names)-1], restoredResource)
+	m.indexTree(restoredResource)
+	m.emitEvent(EVENT_TYPE_CREATED, restoredResource, destinationPath, "", modifier)
+	destinationParentResource.touch(modifier)
+	return destinationResource != nil, nil
+}
+
+/*
+리소스 추가로 인해 저장 용량 제한을 넘는지 확인
+상위 폴더, 소유할 유저, 소유할 그룹의 제한을 모두 확인
+제한을 넘으면 ErrQuotaExceeded
+*/
+func (m *ResourceManager) checkQuota(change quotaChange) error {
+	removedUsage := QuotaUsage{}
+	if change.removed != nil {
+		removedUsage = QuotaUsage{Bytes: change.removed.size, Nodes: change.removed.countNodes()}
+	}
+
+	for resource := change.parent; resource != nil; resource = resource.parent {
+		if !resource.quota.IsLimited() {
+			continue
+		}
+		// 옮기는 리소스를 이미 포함하는 폴더는 사용량이 변하지 않음
+		if change.moved != nil && strings.HasPrefix(change.moved.GetPath(), strings.TrimSuffix(resource.GetPath(), "/")+"/") {
+			continue
+		}
+
+		usage := resource.GetQuotaUsage()
+		usage.Bytes -= removedUsage.Bytes
+		usage.Nodes -= removedUsage.Nodes
+		if resource.quota.isExceeded(usage, change.bytes, change.nodes) {
+			return ErrQuotaExceeded
+		}
+	}
+
+	ownerQuotas := []struct {
+		owner   string
+		isGroup bool
+		quota   ResourceQuota
+	}{
+		{change.owner, false, m.userQuotas[change.owner]},
+		{change.ownerGroup, true, m.groupQuotas[change.ownerGroup]},
+	}
+	for _, ownerQuota := range ownerQuotas {
+		if ownerQuota.owner == "" || !ownerQuota.quota.IsLimited() {
+			continue
+		}
+
+		usage := m.ownerUsageIndex.get(ownerQuota.owner, ownerQuota.isGroup)
+		if change.removed != nil {
+			removedOwnerUsage := QuotaUsage{}
+			change.removed.collectOwnerUsage(ownerQuota.owner, ownerQuota.isGroup, &removedOwnerUsage)
+			usage.Bytes -= removedOwnerUsage.Bytes
+			usage.Nodes -= removedOwnerUsage.Nodes
+		}
+		if ownerQuota.quota.isExceeded(usage, change.bytes, change.nodes) {
+			return ErrQuotaExceeded
+		}
+	}
+	return nil
+}
+
+/*
+경로에 해당 크기의 내용을 쓸 수 있는지 저장 용량 제한 확인
+파일이 없으면 새로 생성할 때를 기준으로 확인
+*/
+func (m *ResourceManager) CheckContentQuota(path string, owner string, ownerGroup string, size int64) error {
+	m, unlock := m.rlockTree()
+	defer unlock()
+
+	resource := m.GetResourceObject(path)
+	if resource != nil {
+		return m.checkQuota(quotaChange{
+			parent:     resource.parent,
+			owner:      resource.creator,
+			ownerGroup: resource.creatorGroup,
+			bytes:      size - resource.size,
+		})
+	}
+
+	parentResource := m.GetNearestExistingParent(path)
+	if parentResource == nil {
+		return ErrInvalidPath
+	}
+	return m.checkQuota(quotaChange{
+		parent:     parentResource,
+		owner:      owner,
+		ownerGroup: ownerGroup,
+		bytes:      size,
+		nodes:      1,
+	})
+}
+
+/*
+유저의 저장 용량 제한 반환
+*/
+func (m *ResourceManager) GetUserQuota(username string) ResourceQuota {
+	m, unlock := m.rlockTree()
+	defer unlock()
+	return m.userQuotas[username]
+}
+
+/*
+유저의 저장 용량 제한 변경
+제한이 없으면 제한 삭제
+*/
+func (m *ResourceManager) SetUserQuota(username string, quota ResourceQuota) {
+	m, unlock, err := m.lockTree()
+	if err != nil {
+		return
+	}
+	defer unlock()
+
+	if quota.IsLimited() {
+		m.userQuotas[username] = quota
+	} else {
+		delete(m.userQuotas, username)
+	}
+}
+
+/*
+그룹의 저장 용량 제한 반환
+*/
+func (m *ResourceManager) GetGroupQuota(groupname string) ResourceQuota {
+	m, unlock := m.rlockTree()
+	defer unlock()
+	return m.groupQuotas[groupname]
+}
+
+/*
+그룹의 저장 용량 제한 변경
+제한이 없으면 제한 삭제
+*/
+func (m *ResourceManager) SetGroupQuota(groupname string, quota ResourceQuota) {
+	m, unlock, err := m.lockTree()
+	if err != nil {
+		return
+	}
+	defer unlock()
+
+	if quota.IsLimited() {
+		m.groupQuotas[groupname] = quota
+	} else {
+		delete(m.groupQuotas, groupname)
+	}
+}
+
+/*
+폴더의 저장 용량 제한 변경
+*/
+func (m *ResourceManager) SetDirectoryQuota(path string, quota ResourceQuota) error {
+	m, unlock, err := m.lockTree()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	resource := m.GetResourceObject(path)
+	if resource == nil {
+		return ErrNotFound
+	}
+	if !resource.IsDirectory() {
+		return ErrNotDirectory
+	}
+
+	resource.quota = quota
+	return nil
+}
+
+/*
+저장 용량 제한이 있는 유저 이름 목록 반환
+*/
+func (m *ResourceManager) GetQuotaUsers() []string {
+	m, unlock := m.rlockTree()
+	defer unlock()
+
+	usernames := []string{}
+	for username := range m.userQuotas {
+		usernames = append(usernames, username)
+	}
+	sort.Strings(usernames)
+	return usernames
+}
+
+/*
+저장 용량 제한이 있는 그룹 이름 목록 반환
+*/
+func (m *ResourceManager) GetQuotaGroups() []string {
+	m, unlock := m.rlockTree()
+	defer unlock()
+
+	groupnames := []string{}
+	for groupname := range m.groupQuotas {
+		groupnames = append(groupnames, groupname)
+	}
+	sort.Strings(groupnames)
+	return groupnames
+}
+
+/*
+저장 용량 제한이 있는 폴더 목록을 경로순으로 반환
+*/
+func (m *ResourceManager) GetQuotaDirectories() []*ResourceObject {
+	m, unlock := m.rlockTree()
+	defer unlock()
+
+	directories := []*ResourceObject{}
+	m.Walk("/", func(resource *ResourceObject, depth int) error {
+		if resource.quota.IsLimited() {
+			directories = append(directories, resource)
+		}
+		return nil
+	})
+	return directories
+}
+
+/*
+유저가 생성한 리소스의 사용량 반환
+*/
+func (m *ResourceManager) GetUserQuotaUsage(username string) QuotaUsage {
+	m, unlock := m.rlockTree()
+	defer unlock()
+
+	return m.ownerUsageIndex.get(username, false)
+}
+
+/*
+그룹에 속한 유저가 생성한 리소스의 사용량 반환
+*/
+func (m *ResourceManager) GetGroupQuotaUsage(groupname string) QuotaUsage {
+	m, unlock := m.rlockTree()
+	defer unlock()
+
+	return m.ownerUsageIndex.get(groupname, true)
+}
+
+/*
+어떤 리소스도 참조하지 않는 내용을 저장소에서 삭제
+잠금 없이 내용을 저장 중인 작업이 있으면 삭제하지 않음
+  - @return {int} 삭제한 내용 개수
+*/
+func (m *ResourceManager) CollectGarbage() int {
+	m, unlock, err := m.lockTree()
+	if err != nil {
+		return 0
+	}
+	defer unlock()
+
+	// 아직 리소스에 연결하지 않은 내용을 삭제하지 않도록 저장 중인 작업이 끝난 후 정리
+	if m.pendingWrites.Load() > 0 {
+		return 0
+	}
+	hashes, err := m.contentStore.List()
+	if err != nil {
+		return 0
+	}
+
+	referencedHashes := m.collectReferencedHashes()
+	count := 0
+	for _, hash := range hashes {
+		if referencedHashes[hash] {
+			continue
+		}
+		if m.contentStore.Delete(hash) == nil {
+			count++
+		}
+	}
+	return count
+}
+
+/*
+트리, 휴지통, 스냅샷이 참조하는 내용의 해시 반환
+*/
+func (m *ResourceManager) collectReferencedHashes() map[string]bool {
+	referencedHashes := map[string]bool{}
+	m.rootResource.collectContentHashes(referencedHashes)
+	for _, item := range m.trashItems {
+		item.resource.collectContentHashes(referencedHashes)
+	}
+	for _, snapshot := range m.snapshots {
+		snapshot.root.collectContentHashes(referencedHashes)
+	}
+	return referencedHashes
+}
+
+/*
+트리, 휴지통, 스냅샷이 참조하는 내용이 모두 저장소에 있는지 확인
+저장한 트리를 내용이 없는 저장소와 함께 불러오면 파일을 읽을 수 없으므로 불러온 직후 확인
+  - @return {error} 저장소에 없는 내용이 있으면 ErrContentMissing
+*/
+func (m *ResourceManager) CheckContentStore() error {
+	m, unlock := m.rlockTree()
+	defer unlock()
+
+	hashes, err := m.contentStore.List()
+	if err != nil {
+		return err
+	}
+	storedHashes := map[string]bool{storage.EmptyBlobHash: true}
+	for _, hash := range hashes {
+		storedHashes[hash] = true
+	}
+
+	missingCount := 0
+	for hash := range m.collectReferencedHashes() {
+		if !storedHashes[hash] {
+			missingCount++
+		}
+	}
+	if missingCount > 0 {
+		return fmt.Errorf("%w: %d개", ErrContentMissing, missingCount)
+	}
+	return nil
+}
+
+/*
+경로의 파일 내용 반환
+사용이 끝나면 Close 해야 함
+*/
+func (m *ResourceManager) OpenContent(path string) (io.ReadSeekCloser, error) {
+	m, unlock := m.rlockTree()
+	defer unlock()
+
+	resource := m.GetResourceObject(path)
+	if resource == nil {
+		return nil, ErrNotFound
+	}
+	if !resource.IsFile() {
+		return nil, ErrNotFile
+	}
+
+	return m.contentStore.Open(resource.GetContentHash())
+}
+
+/*
+파일 내용 저장소 반환
+*/
+func (m *ResourceManager) GetContentStore() storage.BlobStore {
+	m, unlock := m.rlockTree()
+	defer unlock()
+	return m.contentStore
+}
+
+/*
+파일 내용 저장소 변경
+*/
+func (m *ResourceManager) SetContentStore(contentStore storage.BlobStore) {
+	m, unlock, err := m.lockTree()
+	if err != nil {
+		return
+	}
+	defer unlock()
+	m.contentStore = contentStore
+}
+
+/*
+Map화
+*/
+func (m *ResourceManager) ToMap() map[string]any {
+	m, unlock := m.rlockTree()
+	defer unlock()
+
+	trashItems := []map[string]any{}
+	for _, item := range m.trashItems {
+		trashItems = append(trashItems, item.ToMap())
+	}
+	snapshots := []map[string]any{}
+	for _, snapshot := range m.snapshots {
+		snapshots = append(snapshots, snapshot.ToMap())
+	}
+	resourceManagerMap := map[string]any{
+		"rootResource":  m.rootResource.ToMap(),
+		"snapshots":     snapshots,
+		"versionPolicy": m.versionPolicy.ToMap(),
+		"trashItems":    trashItems,
+		"trashPolicy":   m.trashPolicy.ToMap(),
+		"userQuotas":    quotaMapToMap(m.userQuotas),
+		"groupQuotas":   quotaMapToMap(m.groupQuotas),
+	}
+	return resourceManagerMap
+}
+
+/*
+JSON화
+*/
+func (m *ResourceManager) ToJson() (string, error) {
+	m, unlock := m.rlockTree()
+	defer unlock()
+
+	jsonData, err := json.Marshal(m.ToMap())
+
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonData), err
+}
+
+/*
+ResourceManager 생성자 함수
+*/
+func NewResourceManager() *ResourceManager {
+	m := &ResourceManager{
+		resourceManagerState: &resourceManagerState{
+			rootResource: NewResourceObject(ResourceConstructorParam{
+				Name:               "",
+				Path:               "/",
+				IsDirectory:        true,
+				UserPermissionMap:  map[string][]string{},
+				GroupPermissionMap: map[string][]string{},
+			}),
+			contentStore:  storage.NewMemoryBlobStore(),
+			versionPolicy: DefaultVersionPolicy,
+			trashItems:    []TrashItem{},
+			trashPolicy:   DefaultTrashPolicy,
+			snapshots:     []ResourceSnapshot{},
+			userQuotas:    map[string]ResourceQuota{},
+			groupQuotas:   map[string]ResourceQuota{},
+			eventBus:      NewEventBus(DEFAULT_EVENT_BUFFER_SIZE),
+		},
+		ctx: context.Background(),
+	}
+	m.permissionIndex = newPermissionIndex(m.rootResource)
+	m.ownerUsageIndex = newOwnerUsageIndex(m.rootResource)
+	return m
+}
+
+/*
+JSON으로 ResourceManager 생성
+*/
+func FromJsonResourceManager(jsonData string) *ResourceManager {
+	var resourceManagerMap map[string]any
+	json.Unmarshal([]byte(jsonData), &resourceManagerMap)
+
+	rootResource := FromMapResourceObject(resourceManagerMap["rootResource"].(map[string]any))
+	versionPolicyMap, _ := resourceManagerMap["versionPolicy"].(map[string]any)
+	trashPolicyMap, _ := resourceManagerMap["trashPolicy"].(map[string]any)
+
+	trashItems := []TrashItem{}
+	trashItemMaps, _ := resourceManagerMap["trashItems"].([]any)
+	for _, value := range trashItemMaps {
+		trashItemMap, ok := value.(map[string]any)
+		if !ok {
+			continue
+		}
+		if item, ok := FromMapTrashItem(trashItemMap); ok {
+			trashItems = append(trashItems, item)
+		}
+	}
+
+	snapshots := []ResourceSnapshot{}
+	snapshotMaps, _ := resourceManagerMap["snapshots"].([]any)
+	for _, value := range snapshotMaps {
+		snapshotMap, ok := value.(map[string]any)
+		if !ok {
+			continue
+		}
+		if snapshot, ok := FromMapResourceSnapshot(snapshotMap); ok {
+			snapshots = append(snapshots, snapshot)
+		}
+	}
 
-	rootResource := FromMapResourceObject(resourceManagerMap["rootResource"].(map[string]any))
 	m := &ResourceManager{
-		rootResource: rootResource,
+		resourceManagerState: &resourceManagerState{
+			rootResource:  rootResource,
+			contentStore:  storage.NewMemoryBlobStore(),
+			versionPolicy: FromMapVersionPolicy(versionPolicyMap),
+			trashItems:    trashItems,
+			trashPolicy:   FromMapTrashPolicy(trashPolicyMap),
+			snapshots:     snapshots,
+			userQuotas:    fromMapQuotaMap(resourceManagerMap["userQuotas"]),
+			groupQuotas:   fromMapQuotaMap(resourceManagerMap["groupQuotas"]),
+			eventBus:      NewEventBus(DEFAULT_EVENT_BUFFER_SIZE),
+		},
+		ctx: context.Background(),
 	}
+	m.permissionIndex = newPermissionIndex(m.rootResource)
+	m.ownerUsageIndex = newOwnerUsageIndex(m.rootResource)
 	return m
 }
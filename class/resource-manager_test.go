@@ -0,0 +1,482 @@
+package class
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+/*
+테스트용 리소스 트리 생성
+/docs 폴더 아래에 /docs/a.txt, /docs/sub/b.txt 파일이 있음
+*/
+func newTestResourceManager(t *testing.T) *ResourceManager {
+	t.Helper()
+
+	m := NewResourceManager()
+	for _, path := range []string{"/docs/a.txt", "/docs/sub/b.txt"} {
+		if _, err := m.PutContentWithLockTokens(path, strings.NewReader("content"), "alice", "staff", nil); errors.Is(err, ErrParentNotFound) {
+			if _, err := m.CreateResourceWithLockTokens(path[:strings.LastIndex(path, "/")], true, false, "alice", "staff", nil); err != nil {
+				t.Fatalf("폴더 생성 실패: %v", err)
+			}
+			if _, err := m.PutContentWithLockTokens(path, strings.NewReader("content"), "alice", "staff", nil); err != nil {
+				t.Fatalf("파일 생성 실패: %v", err)
+			}
+		} else if err != nil {
+			t.Fatalf("파일 생성 실패: %v", err)
+		}
+	}
+	return m
+}
+
+/*
+테스트용 잠금 후 제출할 잠금 토큰 목록 반환
+path가 비어있으면 잠그지 않으며 withToken이 false이면 빈 목록 반환
+*/
+func lockTestResource(t *testing.T, m *ResourceManager, path string, isDepthInfinity bool, withToken bool) []string {
+	t.Helper()
+
+	if path == "" {
+		return []string{}
+	}
+	success, lockToken := m.Lock(path, isDepthInfinity)
+	if !success {
+		t.Fatalf("%s 잠금 실패", path)
+	}
+	if !withToken {
+		return []string{}
+	}
+	return []string{lockToken}
+}
+
+func TestCreateResourceWithLockTokens(t *testing.T) {
+	tests := []struct {
+		name       string
+		lockPath   string
+		path       string
+		isStrict   bool
+		withToken  bool
+		wantErr    error
+		wantExists bool
+	}{
+		{name: "잠기지 않은 폴더", path: "/docs/new.txt", wantExists: true},
+		{name: "잠긴 부모 폴더", lockPath: "/docs", path: "/docs/new.txt", wantErr: ErrLocked},
+		{name: "잠긴 부모 폴더의 토큰 제출", lockPath: "/docs", path: "/docs/new.txt", withToken: true, wantExists: true},
+		{name: "상위 폴더를 함께 생성할 때 가장 가까운 폴더가 잠김", lockPath: "/docs", path: "/docs/x/y.txt", wantErr: ErrLocked},
+		{name: "다른 폴더의 잠금", lockPath: "/docs/sub", path: "/docs/new.txt", wantExists: true},
+		{name: "상위 폴더가 없는 엄격한 생성", path: "/docs/x/y.txt", isStrict: true, wantErr: ErrParentNotFound},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := newTestResourceManager(t)
+			lockTokens := lockTestResource(t, m, test.lockPath, false, test.withToken)
+
+			_, err := m.CreateResourceWithLockTokens(test.path, false, test.isStrict, "bob", "", lockTokens)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("error = %v, want %v", err, test.wantErr)
+			}
+			if exists := m.GetResourceObject(test.path) != nil; exists != test.wantExists {
+				t.Errorf("exists = %v, want %v", exists, test.wantExists)
+			}
+		})
+	}
+}
+
+func TestDeleteResourceWithLockTokens(t *testing.T) {
+	tests := []struct {
+		name            string
+		lockPath        string
+		isDepthInfinity bool
+		withToken       bool
+		path            string
+		wantErr         error
+	}{
+		{name: "잠기지 않은 폴더", path: "/docs"},
+		{name: "잠긴 하위 파일", lockPath: "/docs/sub/b.txt", path: "/docs", wantErr: ErrLocked},
+		{name: "잠긴 하위 파일의 토큰 제출", lockPath: "/docs/sub/b.txt", withToken: true, path: "/docs"},
+		{name: "하위 폴더를 포함한 잠금", lockPath: "/docs/sub", isDepthInfinity: true, path: "/docs", wantErr: ErrLocked},
+		{name: "잠긴 부모 폴더", lockPath: "/docs", path: "/docs/a.txt", wantErr: ErrLocked},
+		{name: "잠긴 부모 폴더의 토큰 제출", lockPath: "/docs", withToken: true, path: "/docs/a.txt"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := newTestResourceManager(t)
+			lockTokens := lockTestResource(t, m, test.lockPath, test.isDepthInfinity, test.withToken)
+
+			err := m.DeleteResourceWithLockTokens(test.path, "alice", lockTokens)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("error = %v, want %v", err, test.wantErr)
+			}
+			// 실패하면 하위 리소스를 포함하여 아무것도 삭제하지 않음
+			wantExists := test.wantErr != nil
+			for _, path := range []string{test.path, "/docs/sub/b.txt"} {
+				if !strings.HasPrefix(path, test.path) {
+					continue
+				}
+				if exists := m.GetResourceObject(path) != nil; exists != wantExists {
+					t.Errorf("%s exists = %v, want %v", path, exists, wantExists)
+				}
+			}
+		})
+	}
+}
+
+func TestPermissionWithLockTokens(t *testing.T) {
+	tests := []struct {
+		name      string
+		lockPath  string
+		withToken bool
+		wantErr   error
+	}{
+		{name: "잠기지 않은 파일"},
+		{name: "잠긴 파일", lockPath: "/docs/a.txt", wantErr: ErrLocked},
+		{name: "잠긴 파일의 토큰 제출", lockPath: "/docs/a.txt", withToken: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := newTestResourceManager(t)
+			lockTokens := lockTestResource(t, m, test.lockPath, false, test.withToken)
+
+			changes := []struct {
+				name   string
+				change func() error
+			}{
+				{"AddUserPermission", func() error {
+					_, err := m.AddUserPermissionWithLockTokens("/docs/a.txt", "bob", "read", "alice", lockTokens)
+					return err
+				}},
+				{"AddGroupPermission", func() error {
+					_, err := m.AddGroupPermissionWithLockTokens("/docs/a.txt", "staff", "read", "alice", lockTokens)
+					return err
+				}},
+				{"DeleteUserPermission", func() error {
+					return m.DeleteUserPermissionWithLockTokens("/docs/a.txt", "bob", "read", "alice", lockTokens)
+				}},
+				{"DeleteGroupPermission", func() error {
+					return m.DeleteGroupPermissionWithLockTokens("/docs/a.txt", "staff", "read", "alice", lockTokens)
+				}},
+			}
+			for _, change := range changes {
+				if err := change.change(); !errors.Is(err, test.wantErr) {
+					t.Errorf("%s error = %v, want %v", change.name, err, test.wantErr)
+				}
+			}
+			if test.wantErr != nil && m.CheckUserPermission("/docs/a.txt", "bob", "read") {
+				t.Error("잠긴 파일의 권한이 변경됨")
+			}
+		})
+	}
+}
+
+func TestCopyResourceWithLockTokensPermission(t *testing.T) {
+	tests := []struct {
+		name            string
+		sourcePath      string
+		isDepthInfinity bool
+		unreadablePath  string
+		wantErr         error
+	}{
+		{name: "모든 리소스를 읽을 수 있음", sourcePath: "/docs", isDepthInfinity: true},
+		{name: "읽을 수 없는 하위 파일", sourcePath: "/docs", isDepthInfinity: true, unreadablePath: "/docs/sub/b.txt", wantErr: ErrPermissionDenied},
+		{name: "하위 리소스를 복사하지 않으면 하위 파일 권한은 확인하지 않음", sourcePath: "/docs", unreadablePath: "/docs/sub/b.txt"},
+		{name: "읽을 수 없는 원본", sourcePath: "/docs/a.txt", unreadablePath: "/docs/a.txt", wantErr: ErrPermissionDenied},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := newTestResourceManager(t)
+			for _, path := range []string{"/docs", "/docs/a.txt", "/docs/sub", "/docs/sub/b.txt"} {
+				if path != test.unreadablePath {
+					m.AddUserPermission(path, "bob", "read")
+				}
+			}
+
+			_, err := m.CopyResourceWithLockTokens(test.sourcePath, "/copy", false, test.isDepthInfinity, "bob", "", nil)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("error = %v, want %v", err, test.wantErr)
+			}
+			if exists := m.GetResourceObject("/copy") != nil; exists != (test.wantErr == nil) {
+				t.Errorf("exists = %v, want %v", exists, test.wantErr == nil)
+			}
+		})
+	}
+}
+
+func TestTrashItemsFilter(t *testing.T) {
+	tests := []struct {
+		name      string
+		deleter   string
+		wantPaths []string
+	}{
+		{name: "전체", deleter: "", wantPaths: []string{"/docs/sub/b.txt", "/docs/a.txt"}},
+		{name: "alice가 삭제한 항목", deleter: "alice", wantPaths: []string{"/docs/a.txt"}},
+		{name: "bob이 삭제한 항목", deleter: "bob", wantPaths: []string{"/docs/sub/b.txt"}},
+		{name: "삭제한 항목이 없는 유저", deleter: "carol", wantPaths: []string{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := newTestResourceManager(t)
+			if err := m.DeleteResourceWithLockTokens("/docs/a.txt", "alice", nil); err != nil {
+				t.Fatal(err)
+			}
+			if err := m.DeleteResourceWithLockTokens("/docs/sub/b.txt", "bob", nil); err != nil {
+				t.Fatal(err)
+			}
+
+			if paths := getTrashItemPaths(m.GetTrashItems(test.deleter)); strings.Join(paths, ",") != strings.Join(test.wantPaths, ",") {
+				t.Errorf("GetTrashItems = %v, want %v", paths, test.wantPaths)
+			}
+
+			// 해당 유저의 항목만 영구 삭제하고 나머지는 남김
+			purgedPaths := getTrashItemPaths(m.PurgeTrash(test.deleter))
+			if len(purgedPaths) != len(test.wantPaths) {
+				t.Errorf("PurgeTrash = %v, want %v", purgedPaths, test.wantPaths)
+			}
+			if remaining := len(m.GetTrashItems("")); remaining != 2-len(test.wantPaths) {
+				t.Errorf("남은 항목 개수 = %d, want %d", remaining, 2-len(test.wantPaths))
+			}
+		})
+	}
+}
+
+/*
+휴지통 항목의 원래 경로 목록 반환
+*/
+func getTrashItemPaths(items []TrashItem) []string {
+	paths := []string{}
+	for _, item := range items {
+		paths = append(paths, item.GetOriginalPath())
+	}
+	return paths
+}
+
+func TestPutContentWithLockTokens(t *testing.T) {
+	tests := []struct {
+		name        string
+		path        string
+		content     string
+		maxBytes    int64
+		wantErr     error
+		wantCreated bool
+		wantSize    int64
+	}{
+		{name: "새 파일", path: "/docs/new.txt", content: "hello", wantCreated: true, wantSize: 5},
+		{name: "기존 파일", path: "/docs/a.txt", content: "hello", wantSize: 5},
+		{name: "상위 폴더가 없음", path: "/none/new.txt", content: "hello", wantErr: ErrParentNotFound},
+		{name: "폴더", path: "/docs/sub", content: "hello", wantErr: ErrNotFile},
+		{name: "용량 초과", path: "/docs/new.txt", content: "too large content", maxBytes: 10, wantErr: ErrQuotaExceeded},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := newTestResourceManager(t)
+			if test.maxBytes > 0 {
+				m.SetUserQuota("bob", ResourceQuota{MaxBytes: test.maxBytes})
+			}
+			blobsBefore, _ := m.GetContentStore().List()
+
+			isCreated, err := m.PutContentWithLockTokens(test.path, strings.NewReader(test.content), "bob", "", nil)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("error = %v, want %v", err, test.wantErr)
+			}
+			if isCreated != test.wantCreated {
+				t.Errorf("isCreated = %v, want %v", isCreated, test.wantCreated)
+			}
+
+			resource := m.GetResourceObject(test.path)
+			if test.wantErr != nil {
+				// 실패하면 파일과 저장한 내용이 남지 않음
+				if test.path != "/docs/sub" && resource != nil {
+					t.Error("실패한 업로드의 파일이 남음")
+				}
+				if blobs, _ := m.GetContentStore().List(); len(blobs) != len(blobsBefore) {
+					t.Errorf("저장된 내용 개수 = %d, want %d", len(blobs), len(blobsBefore))
+				}
+				return
+			}
+			if resource == nil || resource.GetSize() != test.wantSize || resource.GetContentHash() == "" {
+				t.Fatalf("업로드한 파일의 내용이 연결되지 않음")
+			}
+		})
+	}
+}
+
+func TestPutContentWithLockTokensLock(t *testing.T) {
+	tests := []struct {
+		name      string
+		lockPath  string
+		path      string
+		withToken bool
+		wantErr   error
+	}{
+		{name: "잠긴 부모 폴더에 생성", lockPath: "/docs", path: "/docs/new.txt", wantErr: ErrLocked},
+		{name: "잠긴 부모 폴더의 토큰 제출", lockPath: "/docs", path: "/docs/new.txt", withToken: true},
+		{name: "잠긴 파일의 내용 변경", lockPath: "/docs/a.txt", path: "/docs/a.txt", wantErr: ErrLocked},
+		{name: "잠긴 파일의 토큰 제출", lockPath: "/docs/a.txt", path: "/docs/a.txt", withToken: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := newTestResourceManager(t)
+			lockTokens := lockTestResource(t, m, test.lockPath, false, test.withToken)
+
+			_, err := m.PutContentWithLockTokens(test.path, strings.NewReader("hello"), "bob", "", lockTokens)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("error = %v, want %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestViewRejectsWrites(t *testing.T) {
+	m := newTestResourceManager(t)
+
+	m.View(func(m *ResourceManager) {
+		if err := m.DeleteResourceWithLockTokens("/docs/a.txt", "alice", nil); !errors.Is(err, ErrReadOnlyView) {
+			t.Errorf("DeleteResourceWithLockTokens error = %v, want %v", err, ErrReadOnlyView)
+		}
+	})
+	if m.GetResourceObject("/docs/a.txt") == nil {
+		t.Error("View 안에서 리소스가 삭제됨")
+	}
+}
+
+func TestCheckContentStore(t *testing.T) {
+	m := newTestResourceManager(t)
+	if err := m.CheckContentStore(); err != nil {
+		t.Fatalf("CheckContentStore error = %v", err)
+	}
+
+	// 저장한 트리를 빈 저장소와 함께 불러오면 내용이 없음
+	jsonData, err := m.ToJson()
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded := FromJsonResourceManager(jsonData)
+	if err := loaded.CheckContentStore(); !errors.Is(err, ErrContentMissing) {
+		t.Errorf("CheckContentStore error = %v, want %v", err, ErrContentMissing)
+	}
+}
+
+func TestUnlockForceAllKeepsInheritedLocks(t *testing.T) {
+	m := newTestResourceManager(t)
+	for _, param := range []struct {
+		path  string
+		owner string
+	}{
+		{"/docs", "alice"},
+		{"/docs/sub/b.txt", "bob"},
+	} {
+		success, _ := m.LockWithParam(param.path, LockParam{IsDepthInfinity: true, Owner: param.owner, Scope: LOCK_SCOPE_SHARED})
+		if !success {
+			t.Fatalf("%s 잠금 실패", param.path)
+		}
+	}
+
+	if locks := m.UnlockForceAll("bob"); len(locks) != 1 || locks[0].GetOwner() != "bob" {
+		t.Fatalf("UnlockForceAll = %v, want bob의 잠금 1개", locks)
+	}
+	// /docs에 건 잠금은 하위 리소스에서도 유지
+	locks := m.GetResourceObject("/docs/sub/b.txt").GetLocks()
+	if len(locks) != 1 || locks[0].GetOwner() != "alice" {
+		t.Errorf("/docs/sub/b.txt 잠금 = %v, want alice의 잠금 1개", locks)
+	}
+
+	if !m.UnlockForce("/docs") {
+		t.Error("UnlockForce = false, want true")
+	}
+	if m.UnlockForce("/docs") {
+		t.Error("잠금이 없는데 UnlockForce = true")
+	}
+}
+
+func TestOwnerUsageIndex(t *testing.T) {
+	m := newTestResourceManager(t)
+	for _, path := range []string{"/docs", "/docs/a.txt", "/docs/sub", "/docs/sub/b.txt"} {
+		m.AddUserPermission(path, "alice", "read")
+	}
+
+	// 집계한 사용량은 트리 전체를 순회한 사용량과 같아야 함
+	checkUsage := func(step string) {
+		t.Helper()
+		for _, owner := range []struct {
+			name    string
+			isGroup bool
+		}{
+			{"alice", false},
+			{"bob", false},
+			{"staff", true},
+			{"guest", true},
+		} {
+			want := QuotaUsage{}
+			m.rootResource.collectOwnerUsage(owner.name, owner.isGroup, &want)
+			if got := m.ownerUsageIndex.get(owner.name, owner.isGroup); got != want {
+				t.Errorf("%s: %s 사용량 = %+v, want %+v", step, owner.name, got, want)
+			}
+		}
+	}
+	checkUsage("생성")
+
+	if _, err := m.PutContentWithLockTokens("/docs/a.txt", strings.NewReader("longer content"), "bob", "guest", nil); err != nil {
+		t.Fatal(err)
+	}
+	checkUsage("덮어쓰기")
+	if err := m.RestoreVersionWithLockTokens("/docs/a.txt", 1, "bob", nil); err != nil {
+		t.Fatal(err)
+	}
+	checkUsage("버전 복원")
+	if _, err := m.CopyResourceWithLockTokens("/docs", "/copy", false, true, "alice", "guest", nil); err != nil {
+		t.Fatal(err)
+	}
+	checkUsage("복사")
+	if _, err := m.MoveResourceWithLockTokens("/copy/sub", "/docs/moved", false, "alice", nil); err != nil {
+		t.Fatal(err)
+	}
+	checkUsage("이동")
+	if err := m.DeleteResourceWithLockTokens("/docs/sub", "alice", nil); err != nil {
+		t.Fatal(err)
+	}
+	checkUsage("삭제")
+	items := m.GetTrashItems("alice")
+	if len(items) != 1 {
+		t.Fatalf("휴지통 항목 = %d개, want 1", len(items))
+	}
+	if _, err := m.RestoreTrashItemWithLockTokens(items[0].GetId(), "", false, "alice", nil); err != nil {
+		t.Fatal(err)
+	}
+	checkUsage("휴지통 복원")
+}
+
+func TestExpiredTrashItems(t *testing.T) {
+	m := newTestResourceManager(t)
+	if err := m.DeleteResourceWithLockTokens("/docs/a.txt", "alice", nil); err != nil {
+		t.Fatal(err)
+	}
+	m.trashItems[0].deletedAt = time.Now().Add(-2 * DefaultTrashPolicy.MaxAge)
+	id := m.trashItems[0].id
+
+	// 읽기 잠금만 잡으므로 조회할 때는 제외만 하고 삭제하지 않음
+	m.View(func(m *ResourceManager) {
+		if items := m.GetTrashItems(""); len(items) != 0 {
+			t.Errorf("GetTrashItems = %d개, want 0", len(items))
+		}
+		if _, ok := m.GetTrashItem(id); ok {
+			t.Error("보관 기간이 지난 항목을 GetTrashItem이 반환함")
+		}
+	})
+	if len(m.trashItems) != 1 {
+		t.Fatalf("조회 후 휴지통 항목 = %d개, want 1", len(m.trashItems))
+	}
+
+	if _, err := m.RestoreTrashItemWithLockTokens(id, "", false, "alice", nil); !errors.Is(err, ErrTrashItemNotFound) {
+		t.Errorf("RestoreTrashItemWithLockTokens error = %v, want %v", err, ErrTrashItemNotFound)
+	}
+	if len(m.trashItems) != 0 {
+		t.Errorf("쓰기 작업 후 휴지통 항목 = %d개, want 0", len(m.trashItems))
+	}
+}
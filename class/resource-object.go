@@ -1,8 +1,12 @@
 package class
 
 import (
+	storage "app/storage"
 	util "app/util"
 	"encoding/json"
+	"sort"
+	"strconv"
+	"time"
 )
 
 type ResourceObject struct {
@@ -12,8 +16,21 @@ type ResourceObject struct {
 	userPermissionMap  map[string]([]string)
 	groupPermissionMap map[string]([]string)
 	childrenMap        map[string](*ResourceObject)
-	isLocked           bool
-	lockToken          string
+	locks              []ResourceLock
+	propertyMap        map[string](map[string]string)
+	parent             *ResourceObject
+	createdAt          time.Time
+	modifiedAt         time.Time
+	creator            string
+	creatorGroup       string
+	lastModifier       string
+	size               int64
+	contentHash        string
+	// 현재 내용의 버전 번호, 내용을 쓴 적이 없으면 0
+	version  int64
+	versions []ResourceVersion
+	// 폴더의 저장 용량 제한
+	quota ResourceQuota
 }
 
 type ResourceConstructorParam struct {
@@ -27,7 +44,7 @@ type ResourceConstructorParam struct {
 /*
 해당 유저가 가진 권한 배열을 반환
 */
-func (p ResourceObject) GetUserPermissions(username string) []string {
+func (p *ResourceObject) GetUserPermissions(username string) []string {
 	permissions := p.userPermissionMap[username]
 	if permissions == nil {
 		permissions = []string{}
@@ -39,7 +56,7 @@ func (p ResourceObject) GetUserPermissions(username string) []string {
 /*
 해당 그룹이 가진 권한 배열을 반환
 */
-func (p ResourceObject) GetGroupPermissions(groupname string) []string {
+func (p *ResourceObject) GetGroupPermissions(groupname string) []string {
 	permissions := p.groupPermissionMap[groupname]
 	if permissions == nil {
 		permissions = []string{}
@@ -51,7 +68,7 @@ func (p ResourceObject) GetGroupPermissions(groupname string) []string {
 /*
 해당 유저가 특정 권한을 가지고 있는지 여부 반환
 */
-func (p ResourceObject) CheckUserPermission(username string, permission string) bool {
+func (p *ResourceObject) CheckUserPermission(username string, permission string) bool {
 	permissions := p.GetUserPermissions(username)
 	hasPermission := false
 	for _, v := range permissions {
@@ -66,7 +83,7 @@ func (p ResourceObject) CheckUserPermission(username string, permission string)
 /*
 해당 그룹이 특정 권한을 가지고 있는지 여부 반환
 */
-func (p ResourceObject) CheckGroupPermission(groupname string, permission string) bool {
+func (p *ResourceObject) CheckGroupPermission(groupname string, permission string) bool {
 	permissions := p.GetGroupPermissions(groupname)
 	hasPermission := false
 	for _, v := range permissions {
@@ -78,6 +95,32 @@ func (p ResourceObject) CheckGroupPermission(groupname string, permission string
 	return hasPermission
 }
 
+/*
+유저 또는 유저가 속한 그룹이 특정 권한이나 "all" 권한을 가지고 있는지 여부 반환
+*/
+func (p *ResourceObject) CheckPermission(username string, groupname string, permission string) bool {
+	return p.CheckUserPermission(username, "all") ||
+		p.CheckUserPermission(username, permission) ||
+		p.CheckGroupPermission(groupname, "all") ||
+		p.CheckGroupPermission(groupname, permission)
+}
+
+/*
+유저 또는 유저가 속한 그룹이 리소스와 모든 하위 리소스에 특정 권한이나 "all" 권한을 가지고 있는지 여부 반환
+*/
+func (p *ResourceObject) CheckPermissionRecursive(username string, groupname string, permission string) bool {
+	if !p.CheckPermission(username, groupname, permission) {
+		return false
+	}
+
+	for _, child := range p.childrenMap {
+		if !child.CheckPermissionRecursive(username, groupname, permission) {
+			return false
+		}
+	}
+	return true
+}
+
 /*
 유저 권한 추가
 */
@@ -99,6 +142,7 @@ func (p *ResourceObject) AddUserPermission(username string, permission string) [
 
 	userPermissions = append(userPermissions, permission)
 	p.userPermissionMap[username] = userPermissions
+	p.modifiedAt = time.Now()
 	return util.CloneSlice(p.GetUserPermissions(username))
 }
 
@@ -123,6 +167,7 @@ func (p *ResourceObject) AddGroupPermission(groupname string, permission string)
 
 	groupPermissions = append(groupPermissions, permission)
 	p.groupPermissionMap[groupname] = groupPermissions
+	p.modifiedAt = time.Now()
 	return util.CloneSlice(p.GetGroupPermissions(groupname))
 }
 
@@ -145,6 +190,7 @@ func (p *ResourceObject) DeleteUserPermission(username string, permission string
 	if permissionIndex >= 0 {
 		userPermissions = append(userPermissions[:permissionIndex], userPermissions[permissionIndex+1:]...)
 		p.userPermissionMap[username] = userPermissions
+		p.modifiedAt = time.Now()
 	}
 }
 
@@ -167,21 +213,169 @@ func (p *ResourceObject) DeleteGroupPermission(groupname string, permission stri
 	if permissionIndex >= 0 {
 		groupPermissions = append(groupPermissions[:permissionIndex], groupPermissions[permissionIndex+1:]...)
 		p.groupPermissionMap[groupname] = groupPermissions
+		p.modifiedAt = time.Now()
 	}
 }
 
+/*
+사용자 정의 속성 값 반환
+속성이 없으면 false
+*/
+func (p *ResourceObject) GetProperty(namespace string, name string) (string, bool) {
+	value, ok := p.propertyMap[namespace][name]
+	return value, ok
+}
+
+/*
+사용자 정의 속성 목록을 네임스페이스, 이름 순으로 반환
+*/
+func (p *ResourceObject) GetProperties() []ResourceProperty {
+	properties := []ResourceProperty{}
+	for namespace, propertyMap := range p.propertyMap {
+		for name, value := range propertyMap {
+			properties = append(properties, ResourceProperty{
+				Namespace: namespace,
+				Name:      name,
+				Value:     value,
+			})
+		}
+	}
+	sort.Slice(properties, func(i, j int) bool {
+		if properties[i].Namespace != properties[j].Namespace {
+			return properties[i].Namespace < properties[j].Namespace
+		}
+		return properties[i].Name < properties[j].Name
+	})
+	return properties
+}
+
+/*
+사용자 정의 속성 설정
+*/
+func (p *ResourceObject) SetProperty(namespace string, name string, value string) {
+	if p.propertyMap[namespace] == nil {
+		p.propertyMap[namespace] = map[string]string{}
+	}
+	p.propertyMap[namespace][name] = value
+	p.modifiedAt = time.Now()
+}
+
+/*
+사용자 정의 속성 삭제
+*/
+func (p *ResourceObject) DeleteProperty(namespace string, name string) {
+	if _, ok := p.propertyMap[namespace][name]; !ok {
+		return
+	}
+
+	delete(p.propertyMap[namespace], name)
+	if len(p.propertyMap[namespace]) == 0 {
+		delete(p.propertyMap, namespace)
+	}
+	p.modifiedAt = time.Now()
+}
+
+/*
+사용자 정의 속성 설정, 삭제를 순서대로 적용
+*/
+func (p *ResourceObject) PatchProperties(patches []PropertyPatch) {
+	for _, patch := range patches {
+		if patch.IsRemove {
+			p.DeleteProperty(patch.Namespace, patch.Name)
+		} else {
+			p.SetProperty(patch.Namespace, patch.Name, patch.Value)
+		}
+	}
+}
+
+/*
+만료되지 않은 잠금 목록 반환
+*/
+func (p *ResourceObject) GetLocks() []ResourceLock {
+	now := time.Now()
+	locks := []ResourceLock{}
+	for _, lock := range p.locks {
+		if !lock.IsExpired(now) {
+			locks = append(locks, lock)
+		}
+	}
+	return locks
+}
+
 /*
 잠금 여부 반환
 */
-func (p ResourceObject) IsLocked() bool {
-	return p.isLocked
+func (p *ResourceObject) IsLocked() bool {
+	return len(p.GetLocks()) > 0
 }
 
 /*
 잠금 토큰 반환
+공유 잠금이 여러 개이면 첫 번째 잠금의 토큰, 잠겨있지 않으면 빈 문자열
+*/
+func (p *ResourceObject) GetLockToken() string {
+	locks := p.GetLocks()
+	if len(locks) == 0 {
+		return ""
+	}
+	return locks[0].token
+}
+
+/*
+제출된 잠금 토큰으로 리소스를 변경할 수 있는지 여부 반환
+잠겨있지 않거나 잠금 토큰 중 하나가 제출된 토큰 중에 있으면 true
+*/
+func (p *ResourceObject) CanModify(lockTokens []string) bool {
+	locks := p.GetLocks()
+	if len(locks) == 0 {
+		return true
+	}
+
+	for _, lock := range locks {
+		for _, lockToken := range lockTokens {
+			if lockToken == lock.token {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+/*
+하위 리소스를 포함하여 제출된 잠금 토큰으로 변경할 수 있는지 여부 반환
+*/
+func (p *ResourceObject) CanModifyRecursive(lockTokens []string) bool {
+	if !p.CanModify(lockTokens) {
+		return false
+	}
+
+	for _, child := range p.childrenMap {
+		if !child.CanModifyRecursive(lockTokens) {
+			return false
+		}
+	}
+	return true
+}
+
+/*
+해당 범위의 잠금을 걸 수 있는지 여부 반환
+배타 잠금은 다른 잠금과 함께 걸 수 없고, 공유 잠금은 공유 잠금끼리만 함께 걸 수 있음
 */
-func (p ResourceObject) GetLockToken() string {
-	return p.lockToken
+func (p *ResourceObject) canLock(scope string, isDepthInfinity bool) bool {
+	for _, lock := range p.GetLocks() {
+		if scope == LOCK_SCOPE_EXCLUSIVE || lock.scope == LOCK_SCOPE_EXCLUSIVE {
+			return false
+		}
+	}
+
+	if isDepthInfinity {
+		for _, child := range p.childrenMap {
+			if !child.canLock(scope, isDepthInfinity) {
+				return false
+			}
+		}
+	}
+	return true
 }
 
 /*
@@ -190,81 +384,498 @@ func (p ResourceObject) GetLockToken() string {
   - @return {string} 잠금 토큰
 */
 func (p *ResourceObject) Lock(isDepthInfinity bool, lockToken string) (bool, string) {
-	if p.IsLocked() {
+	return p.LockWithParam(LockParam{
+		IsDepthInfinity: isDepthInfinity,
+		LockToken:       lockToken,
+	})
+}
+
+/*
+소유자, 범위, 만료 시간을 지정하여 리소스 잠금
+하위 리소스 중 하나라도 잠글 수 없으면 아무것도 잠그지 않음
+  - @return {bool} 잠금 성공 여부
+  - @return {string} 잠금 토큰
+*/
+func (p *ResourceObject) LockWithParam(param LockParam) (bool, string) {
+	scope := param.Scope
+	if scope != LOCK_SCOPE_SHARED {
+		scope = LOCK_SCOPE_EXCLUSIVE
+	}
+	if !p.canLock(scope, param.IsDepthInfinity) {
 		return false, ""
 	}
 
+	lockToken := param.LockToken
 	if lockToken == "" {
 		lockToken = util.GenerateRandomString(25)
 	}
-	if isDepthInfinity {
+	var expiresAt time.Time
+	if param.Timeout > 0 {
+		expiresAt = time.Now().Add(param.Timeout)
+	}
+
+	p.addLock(ResourceLock{
+		token:           lockToken,
+		owner:           param.Owner,
+		scope:           scope,
+		root:            p.path,
+		isDepthInfinity: param.IsDepthInfinity,
+		expiresAt:       expiresAt,
+	})
+
+	return true, lockToken
+}
+
+/*
+리소스에 잠금 추가
+하위 리소스까지 잠그는 잠금이면 하위 리소스에도 추가
+*/
+func (p *ResourceObject) addLock(lock ResourceLock) {
+	p.removeExpiredLocks()
+	p.locks = append(p.locks, lock)
+
+	if lock.isDepthInfinity {
 		for _, child := range p.childrenMap {
-			child.Lock(isDepthInfinity, lockToken)
+			child.addLock(lock)
 		}
+	}
+}
 
+/*
+리소스와 하위 리소스에서 해당 토큰의 잠금 제거
+*/
+func (p *ResourceObject) removeLock(lockToken string) {
+	locks := []ResourceLock{}
+	for _, lock := range p.locks {
+		if lock.token != lockToken {
+			locks = append(locks, lock)
+		}
+	}
+	p.locks = locks
+
+	for _, child := range p.childrenMap {
+		child.removeLock(lockToken)
 	}
-	p.isLocked = true
-	p.lockToken = lockToken
+}
 
-	return true, p.lockToken
+/*
+만료된 잠금 제거
+*/
+func (p *ResourceObject) removeExpiredLocks() {
+	p.locks = p.GetLocks()
+}
+
+/*
+잠금 만료 시간 갱신
+  - @return {bool} 갱신 성공 여부
+*/
+func (p *ResourceObject) RefreshLock(lockToken string, timeout time.Duration) bool {
+	if !p.CanModify([]string{lockToken}) || !p.IsLocked() {
+		return false
+	}
+
+	var expiresAt time.Time
+	if timeout > 0 {
+		expiresAt = time.Now().Add(timeout)
+	}
+	p.setLockExpiresAt(lockToken, expiresAt)
+	return true
+}
+
+/*
+리소스와 하위 리소스에서 해당 토큰의 잠금 만료 시각 변경
+*/
+func (p *ResourceObject) setLockExpiresAt(lockToken string, expiresAt time.Time) {
+	for i := range p.locks {
+		if p.locks[i].token == lockToken {
+			p.locks[i].expiresAt = expiresAt
+		}
+	}
+
+	for _, child := range p.childrenMap {
+		child.setLockExpiresAt(lockToken, expiresAt)
+	}
 }
 
 /*
 리소스 잠금 해제
+하위 리소스까지 잠근 잠금이면 하위 리소스의 잠금도 해제
 */
 func (p *ResourceObject) Unlock(lockToken string) bool {
 	if !p.IsLocked() {
 		return false
 	}
 
-	if lockToken != p.lockToken {
+	if !p.CanModify([]string{lockToken}) {
 		return false
 	}
 
-	p.isLocked = false
-	p.lockToken = ""
+	p.removeLock(lockToken)
 	return true
 }
 
 /*
 리소스 강제 잠금 해제
+리소스에 걸린 모든 잠금을 하위 리소스에서도 해제
 */
 func (p *ResourceObject) UnlockForce() bool {
 	if !p.IsLocked() {
 		return false
 	}
 
-	p.isLocked = false
-	p.lockToken = ""
+	for _, lock := range p.GetLocks() {
+		p.removeLock(lock.token)
+	}
 	return true
 }
 
+/*
+리소스와 하위 리소스에 걸린 잠금을 토큰을 키로 하여 map에 수집
+*/
+func (p *ResourceObject) collectLocks(locksMap map[string]ResourceLock) {
+	for _, lock := range p.GetLocks() {
+		locksMap[lock.token] = lock
+	}
+
+	for _, child := range p.childrenMap {
+		child.collectLocks(locksMap)
+	}
+}
+
 /*
 리소스가 폴더(Directory)인지 여부 반환
 */
-func (p ResourceObject) IsDirectory() bool {
+func (p *ResourceObject) IsDirectory() bool {
 	return p.isDirectory
 }
 
 /*
 리소스가 파일인지 여부 반환
 */
-func (p ResourceObject) IsFile() bool {
+func (p *ResourceObject) IsFile() bool {
 	return !p.isDirectory
 }
 
 /*
 리소스의 경로 반환
 */
-func (p ResourceObject) GetPath() string {
+func (p *ResourceObject) GetPath() string {
 	return p.path
 }
 
+/*
+생성 시각 반환
+*/
+func (p *ResourceObject) GetCreatedAt() time.Time {
+	return p.createdAt
+}
+
+/*
+마지막 수정 시각 반환
+내용, 권한, 속성, 하위 리소스 목록이 바뀌거나 이동하면 갱신됨
+*/
+func (p *ResourceObject) GetModifiedAt() time.Time {
+	return p.modifiedAt
+}
+
+/*
+생성한 유저 반환
+*/
+func (p *ResourceObject) GetCreator() string {
+	return p.creator
+}
+
+/*
+생성한 유저가 생성할 때 속한 그룹 반환
+*/
+func (p *ResourceObject) GetCreatorGroup() string {
+	return p.creatorGroup
+}
+
+/*
+마지막으로 수정한 유저 반환
+*/
+func (p *ResourceObject) GetLastModifier() string {
+	return p.lastModifier
+}
+
+/*
+크기(byte) 반환
+폴더는 모든 하위 파일 크기의 합
+*/
+func (p *ResourceObject) GetSize() int64 {
+	return p.size
+}
+
+/*
+수정 시각과 수정한 유저 갱신
+*/
+func (p *ResourceObject) touch(modifier string) {
+	p.modifiedAt = time.Now()
+	p.lastModifier = modifier
+}
+
+/*
+크기를 변경하고 상위 폴더에 변경량 반영
+*/
+func (p *ResourceObject) addSize(delta int64) {
+	for resource := p; resource != nil; resource = resource.parent {
+		resource.size += delta
+	}
+}
+
+/*
+폴더의 저장 용량 제한 반환
+*/
+func (p *ResourceObject) GetQuota() ResourceQuota {
+	return p.quota
+}
+
+/*
+폴더의 저장 용량 사용량 반환
+크기는 모든 하위 파일 크기의 합, 개수는 폴더 자신을 제외한 모든 하위 리소스의 개수
+*/
+func (p *ResourceObject) GetQuotaUsage() QuotaUsage {
+	return QuotaUsage{
+		Bytes: p.size,
+		Nodes: p.countNodes() - 1,
+	}
+}
+
+/*
+상위 폴더의 저장 용량 제한을 고려하여 더 저장할 수 있는 크기 반환
+크기 제한이 있는 상위 폴더가 없으면 false
+*/
+func (p *ResourceObject) GetQuotaAvailableBytes() (int64, bool) {
+	availableBytes := int64(0)
+	isLimited := false
+	for resource := p; resource != nil; resource = resource.parent {
+		if resource.quota.MaxBytes <= 0 {
+			continue
+		}
+		bytes := resource.quota.MaxBytes - resource.size
+		if bytes < 0 {
+			bytes = 0
+		}
+		if !isLimited || bytes < availableBytes {
+			availableBytes = bytes
+		}
+		isLimited = true
+	}
+	return availableBytes, isLimited
+}
+
+/*
+리소스 자신과 모든 하위 리소스의 개수 반환
+*/
+func (p *ResourceObject) countNodes() int64 {
+	count := int64(1)
+	for _, child := range p.childrenMap {
+		count += child.countNodes()
+	}
+	return count
+}
+
+/*
+리소스와 하위 리소스 중 해당 유저 또는 그룹이 생성한 리소스의 사용량을 더함
+  - @param {bool} isGroup owner가 그룹 이름인지 여부
+*/
+func (p *ResourceObject) collectOwnerUsage(owner string, isGroup bool, usage *QuotaUsage) {
+	if (!isGroup && p.creator == owner) || (isGroup && p.creatorGroup == owner) {
+		usage.Nodes++
+		if p.IsFile() {
+			usage.Bytes += p.size
+		}
+	}
+	for _, child := range p.childrenMap {
+		child.collectOwnerUsage(owner, isGroup, usage)
+	}
+}
+
+/*
+파일 내용의 해시 반환
+내용을 쓴 적이 없으면 빈 내용의 해시
+*/
+func (p *ResourceObject) GetContentHash() string {
+	if p.contentHash == "" {
+		return storage.EmptyBlobHash
+	}
+	return p.contentHash
+}
+
+/*
+ETag 반환
+파일은 내용의 해시로부터 계산한 strong ETag, 폴더는 수정 시각으로부터 계산한 weak ETag
+*/
+func (p *ResourceObject) GetETag() string {
+	if p.IsDirectory() {
+		return "W/\"" + strconv.FormatInt(p.modifiedAt.UnixNano(), 36) + "\""
+	}
+	return getContentETag(p.GetContentHash())
+}
+
+/*
+내용의 해시로부터 strong ETag 계산
+해시가 32자보다 짧으면 해시 전체를 사용
+*/
+func getContentETag(contentHash string) string {
+	if len(contentHash) > 32 {
+		contentHash = contentHash[:32]
+	}
+	return "\"" + contentHash + "\""
+}
+
+/*
+파일 내용이 변경되었을 때 내용의 해시, 크기, 수정 시각, 수정한 유저 갱신
+폴더이면 false
+*/
+func (p *ResourceObject) UpdateContent(contentHash string, size int64, modifier string) bool {
+	if p.IsDirectory() {
+		return false
+	}
+
+	// 현재 내용을 이전 버전으로 보관
+	if p.version > 0 {
+		p.versions = append(p.versions, p.getCurrentVersion())
+	}
+	p.version++
+
+	p.contentHash = contentHash
+	p.addSize(size - p.size)
+	p.touch(modifier)
+	return true
+}
+
+/*
+현재 내용의 버전 번호 반환
+내용을 쓴 적이 없으면 0
+*/
+func (p *ResourceObject) GetVersion() int64 {
+	return p.version
+}
+
+/*
+현재 내용을 버전으로 반환
+*/
+func (p *ResourceObject) getCurrentVersion() ResourceVersion {
+	return ResourceVersion{
+		number:      p.version,
+		contentHash: p.GetContentHash(),
+		size:        p.size,
+		modifier:    p.lastModifier,
+		modifiedAt:  p.modifiedAt,
+	}
+}
+
+/*
+보관된 이전 버전 목록을 최신순으로 반환
+현재 내용은 포함하지 않음
+*/
+func (p *ResourceObject) GetVersions() []ResourceVersion {
+	versions := []ResourceVersion{}
+	for i := len(p.versions) - 1; i >= 0; i-- {
+		versions = append(versions, p.versions[i])
+	}
+	return versions
+}
+
+/*
+해당 번호의 버전 반환
+현재 내용의 번호이면 현재 내용, 보관되지 않은 번호이면 false
+*/
+func (p *ResourceObject) GetVersionByNumber(number int64) (ResourceVersion, bool) {
+	if p.IsFile() && number == p.version {
+		return p.getCurrentVersion(), true
+	}
+	for _, version := range p.versions {
+		if version.number == number {
+			return version, true
+		}
+	}
+	return ResourceVersion{}, false
+}
+
+/*
+보관 정책을 벗어난 이전 버전을 리소스와 하위 리소스에서 제거
+  - @return {int} 제거한 버전 개수
+*/
+func (p *ResourceObject) pruneVersions(policy VersionPolicy, now time.Time) int {
+	count := 0
+	for _, child := range p.childrenMap {
+		count += child.pruneVersions(policy, now)
+	}
+
+	versions := []ResourceVersion{}
+	for _, version := range p.versions {
+		if policy.MaxAge > 0 && now.Sub(version.modifiedAt) > policy.MaxAge {
+			continue
+		}
+		versions = append(versions, version)
+	}
+	if policy.MaxCount >= 0 && len(versions) > policy.MaxCount {
+		versions = versions[len(versions)-policy.MaxCount:]
+	}
+
+	count += len(p.versions) - len(versions)
+	p.versions = versions
+	return count
+}
+
+/*
+리소스와 하위 리소스가 참조하는 내용의 해시를 수집
+이전 버전의 내용도 포함
+*/
+func (p *ResourceObject) collectContentHashes(hashes map[string]bool) {
+	if p.IsFile() {
+		hashes[p.GetContentHash()] = true
+	}
+	for _, version := range p.versions {
+		hashes[version.contentHash] = true
+	}
+	for _, child := range p.childrenMap {
+		child.collectContentHashes(hashes)
+	}
+}
+
+/*
+리소스와 하위 리소스의 경로 변경
+*/
+func (p *ResourceObject) setPath(path string) {
+	p.path = path
+	for name, child := range p.childrenMap {
+		child.setPath(joinPath(path, name))
+	}
+}
+
+/*
+리소스의 이름 반환
+*/
+func (p *ResourceObject) GetName() string {
+	return p.name
+}
+
+/*
+하위 리소스 목록을 이름 순으로 반환
+*/
+func (p *ResourceObject) GetChildren() []*ResourceObject {
+	names := []string{}
+	for name := range p.childrenMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	children := []*ResourceObject{}
+	for _, name := range names {
+		children = append(children, p.childrenMap[name])
+	}
+	return children
+}
+
 /*
 하위 리소스 반환
 값이 없을 수 있으니 `nil`인지 확인할 것
 */
-func (p ResourceObject) GetChild(name string) *ResourceObject {
+func (p *ResourceObject) GetChild(name string) *ResourceObject {
 	return p.childrenMap[name]
 }
 
@@ -283,27 +894,77 @@ func (p *ResourceObject) CreateChild(name string, isDirectory bool) (bool, *Reso
 		return false, nil
 	}
 
-	childPath := ""
-	if p.GetPath() == "/" {
-		childPath = "/" + name
-	} else {
-		childPath = p.GetPath() + "/" + name
-	}
-
 	constructorParam := ResourceConstructorParam{
 		Name:               name,
 		IsDirectory:        isDirectory,
-		Path:               childPath,
-		UserPermissionMap:  p.userPermissionMap,
-		GroupPermissionMap: p.groupPermissionMap,
+		Path:               joinPath(p.GetPath(), name),
+		UserPermissionMap:  clonePermissionMap(p.userPermissionMap),
+		GroupPermissionMap: clonePermissionMap(p.groupPermissionMap),
 	}
 	child = NewResourceObject(constructorParam)
+	// 하위 리소스까지 잠근 잠금은 새 하위 리소스에도 적용
+	for _, lock := range p.GetLocks() {
+		if lock.isDepthInfinity {
+			child.addLock(lock)
+		}
+	}
 
+	child.parent = p
 	p.childrenMap[name] = child
+	p.modifiedAt = child.createdAt
 
 	return true, child
 }
 
+/*
+다른 폴더의 리소스를 이름을 바꾸어 하위 리소스로 옮김
+옮긴 리소스와 하위 리소스에 걸린 잠금은 해제되고, 이 폴더의 하위 리소스까지 잠그는 잠금이 적용됨
+  - @return {bool} 성공 여부, 같은 이름의 하위 리소스가 있으면 false
+*/
+func (p *ResourceObject) AttachChild(name string, child *ResourceObject) bool {
+	if name == "" || p.GetChild(name) != nil {
+		return false
+	}
+
+	if child.parent != nil {
+		child.parent.detachChild(child.name)
+	}
+
+	child.name = name
+	child.setPath(joinPath(p.GetPath(), name))
+	for _, lock := range child.GetLocks() {
+		child.removeLock(lock.token)
+	}
+	for _, lock := range p.GetLocks() {
+		if lock.isDepthInfinity {
+			child.addLock(lock)
+		}
+	}
+
+	child.parent = p
+	p.childrenMap[name] = child
+	p.addSize(child.size)
+	p.modifiedAt = time.Now()
+	return true
+}
+
+/*
+하위 리소스를 목록에서 떼어냄
+떼어낸 리소스는 삭제되지 않음
+*/
+func (p *ResourceObject) detachChild(name string) *ResourceObject {
+	child := p.GetChild(name)
+	if child == nil {
+		return nil
+	}
+
+	delete(p.childrenMap, name)
+	p.addSize(-child.size)
+	p.modifiedAt = time.Now()
+	child.parent = nil
+	return child
+}
+
 /*
 하위 리소스 삭제
 */
@@ -316,19 +977,85 @@ func (p *ResourceObject) DeleteChild(name string) bool {
 	for key := range child.childrenMap { // 혹시몰라서 ㅎㅎ
 		child.DeleteChild(key)
 	}
-	delete(p.childrenMap, name)
+	p.detachChild(name)
 	return true
 }
 
+/*
+리소스와 하위 리소스를 복사
+이름, 권한, 속성, 메타데이터, 내용의 해시를 복사하며 잠금과 이전 버전은 복사하지 않음
+*/
+func (p *ResourceObject) clone() *ResourceObject {
+	propertyMap := map[string](map[string]string){}
+	for namespace, properties := range p.propertyMap {
+		propertyMap[namespace] = map[string]string{}
+		for name, value := range properties {
+			propertyMap[namespace][name] = value
+		}
+	}
+
+	clone := &ResourceObject{
+		isDirectory:        p.isDirectory,
+		path:               p.path,
+		name:               p.name,
+		userPermissionMap:  clonePermissionMap(p.userPermissionMap),
+		groupPermissionMap: clonePermissionMap(p.groupPermissionMap),
+		childrenMap:        map[string](*ResourceObject){},
+		locks:              []ResourceLock{},
+		propertyMap:        propertyMap,
+		createdAt:          p.createdAt,
+		modifiedAt:         p.modifiedAt,
+		creator:            p.creator,
+		creatorGroup:       p.creatorGroup,
+		lastModifier:       p.lastModifier,
+		size:               p.size,
+		contentHash:        p.contentHash,
+		version:            p.version,
+		quota:              p.quota,
+	}
+	for name, child := range p.childrenMap {
+		childClone := child.clone()
+		childClone.parent = clone
+		clone.childrenMap[name] = childClone
+	}
+	return clone
+}
+
+/*
+복사한 리소스와 하위 리소스를 새로 생성한 리소스로 변경
+권한은 새 부모 폴더의 권한을 상속
+*/
+func (p *ResourceObject) resetAsCopy(parent *ResourceObject, creator string, creatorGroup string, now time.Time) {
+	p.userPermissionMap = clonePermissionMap(parent.userPermissionMap)
+	p.groupPermissionMap = clonePermissionMap(parent.groupPermissionMap)
+	p.creator = creator
+	p.creatorGroup = creatorGroup
+	p.lastModifier = creator
+	p.createdAt = now
+	p.modifiedAt = now
+	p.quota = ResourceQuota{}
+	for _, child := range p.childrenMap {
+		child.resetAsCopy(p, creator, creatorGroup, now)
+	}
+}
+
 /*
 Map화
 */
-func (p ResourceObject) ToMap() map[string]any {
+func (p *ResourceObject) ToMap() map[string]any {
 	childrenMap := map[string](map[string]any){}
 	for key, value := range p.childrenMap {
 		childMap := value.ToMap()
 		childrenMap[key] = childMap
 	}
+	locks := []map[string]any{}
+	for _, lock := range p.GetLocks() {
+		locks = append(locks, lock.ToMap())
+	}
+	versions := []map[string]any{}
+	for _, version := range p.versions {
+		versions = append(versions, version.ToMap())
+	}
 	resourceObjectMap := map[string]any{
 		"isDirectory":        p.isDirectory,
 		"path":               p.path,
@@ -336,6 +1063,18 @@ func (p ResourceObject) ToMap() map[string]any {
 		"userPermissionMap":  p.userPermissionMap,
 		"groupPermissionMap": p.groupPermissionMap,
 		"childrenMap":        childrenMap,
+		"locks":              locks,
+		"propertyMap":        p.propertyMap,
+		"createdAt":          formatTime(p.createdAt),
+		"modifiedAt":         formatTime(p.modifiedAt),
+		"creator":            p.creator,
+		"creatorGroup":       p.creatorGroup,
+		"lastModifier":       p.lastModifier,
+		"size":               p.size,
+		"contentHash":        p.contentHash,
+		"version":            p.version,
+		"versions":           versions,
+		"quota":              p.quota.ToMap(),
 	}
 	return resourceObjectMap
 }
@@ -343,7 +1082,7 @@ func (p ResourceObject) ToMap() map[string]any {
 /*
 JSON화
 */
-func (p ResourceObject) ToJSON() (string, any) {
+func (p *ResourceObject) ToJSON() (string, any) {
 	jsonData, err := json.Marshal(p.ToMap())
 
 	if err != nil {
@@ -353,10 +1092,57 @@ func (p ResourceObject) ToJSON() (string, any) {
 	return string(jsonData), err
 }
 
+/*
+폴더 경로와 이름을 합쳐 하위 리소스의 경로 반환
+*/
+func joinPath(parentPath string, name string) string {
+	if parentPath == "/" {
+		return "/" + name
+	}
+	return parentPath + "/" + name
+}
+
+/*
+시각을 저장용 문자열로 변환
+zero value이면 빈 문자열
+*/
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+/*
+저장용 문자열을 시각으로 변환
+올바르지 않으면 zero value
+*/
+func parseTime(value any) time.Time {
+	timeString, _ := value.(string)
+	t, err := time.Parse(time.RFC3339Nano, timeString)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+/*
+권한 map 복사
+하위 리소스가 부모의 권한 map을 공유하면 한쪽의 권한 변경이 다른 쪽에도 반영되므로 복사하여 사용
+*/
+func clonePermissionMap(permissionMap map[string]([]string)) map[string]([]string) {
+	clone := map[string]([]string){}
+	for key, permissions := range permissionMap {
+		clone[key] = util.CloneSlice(permissions)
+	}
+	return clone
+}
+
 /*
 ResourceObject 생성자 함수
 */
 func NewResourceObject(param ResourceConstructorParam) *ResourceObject {
+	now := time.Now()
 	p := &ResourceObject{
 		isDirectory:        param.IsDirectory,
 		path:               param.Path,
@@ -364,8 +1150,10 @@ func NewResourceObject(param ResourceConstructorParam) *ResourceObject {
 		userPermissionMap:  param.UserPermissionMap,
 		groupPermissionMap: param.GroupPermissionMap,
 		childrenMap:        map[string](*ResourceObject){},
-		isLocked:           false,
-		lockToken:          "",
+		locks:              []ResourceLock{},
+		propertyMap:        map[string](map[string]string){},
+		createdAt:          now,
+		modifiedAt:         now,
 	}
 	return p
 }
@@ -410,6 +1198,32 @@ func FromMapResourceObject(resourceObjectMap map[string]any) *ResourceObject {
 		childrenMap[key] = FromMapResourceObject(childMap)
 	}
 
+	// 서버가 내려가 있는 동안 만료된 잠금은 복원하지 않음
+	now := time.Now()
+	locks := []ResourceLock{}
+	lockMaps, _ := resourceObjectMap["locks"].([]any)
+	for _, value := range lockMaps {
+		lockMap, ok := value.(map[string]any)
+		if !ok {
+			continue
+		}
+		lock, ok := FromMapResourceLock(lockMap)
+		if !ok || lock.IsExpired(now) {
+			continue
+		}
+		locks = append(locks, lock)
+	}
+
+	propertyMap := map[string](map[string]string){}
+	namespaceMaps, _ := resourceObjectMap["propertyMap"].(map[string]any)
+	for namespace, value := range namespaceMaps {
+		properties := map[string]string{}
+		for name, propertyValue := range value.(map[string]any) {
+			properties[name] = propertyValue.(string)
+		}
+		propertyMap[namespace] = properties
+	}
+
 	resourceObject := &ResourceObject{
 		isDirectory:        isDirectory,
 		path:               path,
@@ -417,8 +1231,40 @@ func FromMapResourceObject(resourceObjectMap map[string]any) *ResourceObject {
 		userPermissionMap:  userPermissionMap,
 		groupPermissionMap: groupPermissionMap,
 		childrenMap:        childrenMap,
-		isLocked:           false,
-		lockToken:          "",
+		locks:              locks,
+		propertyMap:        propertyMap,
+		createdAt:          parseTime(resourceObjectMap["createdAt"]),
+		modifiedAt:         parseTime(resourceObjectMap["modifiedAt"]),
+	}
+	resourceObject.creator, _ = resourceObjectMap["creator"].(string)
+	resourceObject.creatorGroup, _ = resourceObjectMap["creatorGroup"].(string)
+	if quotaMap, ok := resourceObjectMap["quota"].(map[string]any); ok {
+		resourceObject.quota = FromMapResourceQuota(quotaMap)
+	}
+	resourceObject.lastModifier, _ = resourceObjectMap["lastModifier"].(string)
+	resourceObject.contentHash, _ = resourceObjectMap["contentHash"].(string)
+	if version, ok := resourceObjectMap["version"].(float64); ok {
+		resourceObject.version = int64(version)
+	}
+	versionMaps, _ := resourceObjectMap["versions"].([]any)
+	for _, value := range versionMaps {
+		versionMap, ok := value.(map[string]any)
+		if !ok {
+			continue
+		}
+		if version, ok := FromMapResourceVersion(versionMap); ok {
+			resourceObject.versions = append(resourceObject.versions, version)
+		}
+	}
+
+	// 폴더의 크기는 하위 리소스로부터 다시 계산
+	if isDirectory {
+		for _, child := range childrenMap {
+			child.parent = resourceObject
+			resourceObject.size += child.size
+		}
+	} else if size, ok := resourceObjectMap["size"].(float64); ok {
+		resourceObject.size = int64(size)
 	}
 
 	return resourceObject
@@ -0,0 +1,308 @@
+package class
+
+import (
+	util "app/util"
+	"slices"
+	"sort"
+)
+
+const (
+	PRINCIPAL_TYPE_USER  = "user"
+	PRINCIPAL_TYPE_GROUP = "group"
+)
+
+// 모든 권한을 가지는 권한
+const PERMISSION_ALL = "all"
+
+// 서버에서 확인하는 권한 목록
+var KnownPermissions = []string{"read", "write", "modify", "lock"}
+
+/*
+권한 확인 과정의 한 단계
+리소스의 유저 또는 그룹 항목이 권한을 가지고 있는지 확인한 결과
+*/
+type PermissionCheckStep struct {
+	// 항목이 있는 리소스 경로
+	Path string
+	// PRINCIPAL_TYPE_USER 또는 PRINCIPAL_TYPE_GROUP
+	PrincipalType string
+	Principal     string
+	// 확인한 권한 (예시: "read", "all")
+	Entry     string
+	IsGranted bool
+}
+
+/*
+한 권한의 확인 결과와 확인 과정
+*/
+type PermissionExplanation struct {
+	Permission string
+	IsGranted  bool
+	// CheckPermission과 같은 순서로 확인한 과정
+	Steps []PermissionCheckStep
+}
+
+/*
+유저와 그룹이 리소스에 가지는 실제 권한
+*/
+type EffectivePermissions struct {
+	Path  string
+	User  string
+	Group string
+	// 가지고 있는 권한, "all" 권한이 있으면 모든 권한 포함
+	Permissions  []string
+	Explanations []PermissionExplanation
+}
+
+/*
+특정 권한을 가진 유저 또는 그룹
+*/
+type PermissionPrincipal struct {
+	// PRINCIPAL_TYPE_USER 또는 PRINCIPAL_TYPE_GROUP
+	Type string
+	Name string
+	// 권한을 부여한 항목 (예시: ["read", "all"])
+	Entries []string
+}
+
+/*
+유저 또는 그룹이 권한을 가진 리소스
+*/
+type PrincipalAccess struct {
+	Path        string
+	IsDirectory bool
+	// 가지고 있는 권한, "all" 권한이 있으면 모든 권한 포함
+	Permissions []string
+}
+
+/*
+유저와 그룹이 리소스에 가지는 권한 목록 반환
+"all" 권한이 있으면 KnownPermissions와 "all" 포함
+*/
+func (p *ResourceObject) GetEffectivePermissions(username string, groupname string) []string {
+	entries := []string{}
+	if username != "" {
+		entries = append(entries, p.GetUserPermissions(username)...)
+	}
+	if groupname != "" {
+		entries = append(entries, p.GetGroupPermissions(groupname)...)
+	}
+
+	permissionSet := map[string]bool{}
+	for _, entry := range entries {
+		permissionSet[entry] = true
+		if entry == PERMISSION_ALL {
+			for _, permission := range KnownPermissions {
+				permissionSet[permission] = true
+			}
+		}
+	}
+	permissions := []string{}
+	for permission := range permissionSet {
+		permissions = append(permissions, permission)
+	}
+	sort.Strings(permissions)
+	return permissions
+}
+
+/*
+권한 확인 과정을 CheckPermission과 같은 순서로 반환
+권한은 리소스마다 따로 부여되고 상위 폴더에서 상속되지 않으므로 해당 리소스의 항목만 확인
+*/
+func (p *ResourceObject) ExplainPermission(username string, groupname string, permission string) PermissionExplanation {
+	explanation := PermissionExplanation{
+		Permission: permission,
+		Steps:      []PermissionCheckStep{},
+	}
+
+	entries := []string{PERMISSION_ALL}
+	if permission != PERMISSION_ALL {
+		entries = append(entries, permission)
+	}
+	addSteps := func(principalType string, principal string, check func(string, string) bool) {
+		if principal == "" {
+			return
+		}
+		for _, entry := range entries {
+			isGranted := check(principal, entry)
+			explanation.Steps = append(explanation.Steps, PermissionCheckStep{
+				Path:          p.path,
+				PrincipalType: principalType,
+				Principal:     principal,
+				Entry:         entry,
+				IsGranted:     isGranted,
+			})
+			explanation.IsGranted = explanation.IsGranted || isGranted
+		}
+	}
+	addSteps(PRINCIPAL_TYPE_USER, username, p.CheckUserPermission)
+	addSteps(PRINCIPAL_TYPE_GROUP, groupname, p.CheckGroupPermission)
+
+	return explanation
+}
+
+/*
+경로의 리소스에 대한 유저와 그룹의 실제 권한과 권한별 확인 과정 반환
+KnownPermissions와 유저, 그룹이 가진 그 외의 권한을 모두 설명
+경로에 리소스가 없으면 ErrNotFound
+*/
+func (m *ResourceManager) GetEffectivePermissions(path string, username string, groupname string) (EffectivePermissions, error) {
+	m, unlock := m.rlockTree()
+	defer unlock()
+
+	resource := m.GetResourceObject(path)
+	if resource == nil {
+		return EffectivePermissions{}, ErrNotFound
+	}
+
+	effectivePermissions := resource.GetEffectivePermissions(username, groupname)
+	permissions := util.CloneSlice(KnownPermissions)
+	for _, permission := range effectivePermissions {
+		if !slices.Contains(permissions, permission) && permission != PERMISSION_ALL {
+			permissions = append(permissions, permission)
+		}
+	}
+	permissions = append(permissions, PERMISSION_ALL)
+
+	explanations := []PermissionExplanation{}
+	for _, permission := range permissions {
+		explanations = append(explanations, resource.ExplainPermission(username, groupname, permission))
+	}
+
+	return EffectivePermissions{
+		Path:         resource.GetPath(),
+		User:         username,
+		Group:        groupname,
+		Permissions:  effectivePermissions,
+		Explanations: explanations,
+	}, nil
+}
+
+/*
+경로의 리소스에 특정 권한이나 "all" 권한을 가진 유저와 그룹 목록 반환
+유저, 그룹 순서로 각각 이름순 정렬
+경로에 리소스가 없으면 ErrNotFound
+*/
+func (m *ResourceManager) GetPermissionPrincipals(path string, permission string) ([]PermissionPrincipal, error) {
+	m, unlock := m.rlockTree()
+	defer unlock()
+
+	resource := m.GetResourceObject(path)
+	if resource == nil {
+		return nil, ErrNotFound
+	}
+
+	principals := []PermissionPrincipal{}
+	addPrincipals := func(principalType string, permissionMap map[string]([]string)) {
+		names := []string{}
+		for name := range permissionMap {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			entries := []string{}
+			for _, entry := range permissionMap[name] {
+				if entry == permission || entry == PERMISSION_ALL {
+					entries = append(entries, entry)
+				}
+			}
+			if len(entries) > 0 {
+				principals = append(principals, PermissionPrincipal{
+					Type:    principalType,
+					Name:    name,
+					Entries: entries,
+				})
+			}
+		}
+	}
+	addPrincipals(PRINCIPAL_TYPE_USER, resource.userPermissionMap)
+	addPrincipals(PRINCIPAL_TYPE_GROUP, resource.groupPermissionMap)
+
+	return principals, nil
+}
+
+/*
+유저 또는 그룹이 권한을 하나라도 가진 모든 리소스를 경로순으로 반환
+유저와 그룹을 함께 지정하면 둘 중 하나라도 권한을 가진 리소스 반환
+*/
+func (m *ResourceManager) GetPrincipalAccess(username string, groupname string) []PrincipalAccess {
+	m, unlock := m.rlockTree()
+	defer unlock()
+
+	accesses := []PrincipalAccess{}
+	for _, resource := range m.permissionIndex.getResources(username, groupname) {
+		accesses = append(accesses, PrincipalAccess{
+			Path:        resource.GetPath(),
+			IsDirectory: resource.IsDirectory(),
+			Permissions: resource.GetEffectivePermissions(username, groupname),
+		})
+	}
+	return accesses
+}
+
+/*
+Map화
+*/
+func (s PermissionCheckStep) ToMap() map[string]any {
+	return map[string]any{
+		"path":          s.Path,
+		"principalType": s.PrincipalType,
+		"principal":     s.Principal,
+		"entry":         s.Entry,
+		"isGranted":     s.IsGranted,
+	}
+}
+
+/*
+Map화
+*/
+func (e PermissionExplanation) ToMap() map[string]any {
+	steps := []map[string]any{}
+	for _, step := range e.Steps {
+		steps = append(steps, step.ToMap())
+	}
+	return map[string]any{
+		"permission": e.Permission,
+		"isGranted":  e.IsGranted,
+		"steps":      steps,
+	}
+}
+
+/*
+Map화
+*/
+func (e EffectivePermissions) ToMap() map[string]any {
+	explanations := []map[string]any{}
+	for _, explanation := range e.Explanations {
+		explanations = append(explanations, explanation.ToMap())
+	}
+	return map[string]any{
+		"path":         e.Path,
+		"user":         e.User,
+		"group":        e.Group,
+		"permissions":  e.Permissions,
+		"explanations": explanations,
+	}
+}
+
+/*
+Map화
+*/
+func (p PermissionPrincipal) ToMap() map[string]any {
+	return map[string]any{
+		"type":    p.Type,
+		"name":    p.Name,
+		"entries": p.Entries,
+	}
+}
+
+/*
+Map화
+*/
+func (a PrincipalAccess) ToMap() map[string]any {
+	return map[string]any{
+		"path":        a.Path,
+		"isDirectory": a.IsDirectory,
+		"permissions": a.Permissions,
+	}
+}
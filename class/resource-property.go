@@ -0,0 +1,16 @@
+package class
+
+type ResourceProperty struct {
+	Namespace string
+	Name      string
+	// XML 값
+	Value string
+}
+
+type PropertyPatch struct {
+	Namespace string
+	Name      string
+	// XML 값, IsRemove가 true이면 무시
+	Value    string
+	IsRemove bool
+}
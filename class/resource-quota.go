@@ -0,0 +1,111 @@
+package class
+
+/*
+저장 용량 제한
+*/
+type ResourceQuota struct {
+	// 최대 크기(byte), 0 이하이면 제한 없음
+	MaxBytes int64
+	// 최대 리소스 개수, 0 이하이면 제한 없음
+	MaxNodes int64
+}
+
+/*
+저장 용량 사용량
+*/
+type QuotaUsage struct {
+	Bytes int64
+	Nodes int64
+}
+
+/*
+리소스 추가로 인한 사용량 변화
+*/
+type quotaChange struct {
+	// 리소스가 추가되거나 변경되는 폴더
+	parent *ResourceObject
+	// 추가되는 리소스를 소유할 유저와 그룹, 빈 문자열이면 확인하지 않음
+	owner      string
+	ownerGroup string
+	bytes      int64
+	nodes      int64
+	// 덮어써서 제거되는 리소스
+	removed *ResourceObject
+	// 옮기는 리소스, 이 리소스를 이미 포함하는 폴더는 사용량이 변하지 않음
+	moved *ResourceObject
+}
+
+/*
+제한이 있는지 여부 반환
+*/
+func (q ResourceQuota) IsLimited() bool {
+	return q.MaxBytes > 0 || q.MaxNodes > 0
+}
+
+/*
+사용량이 늘어날 때 제한을 넘는지 여부 반환
+이미 제한을 넘었더라도 사용량이 늘지 않으면 false
+*/
+func (q ResourceQuota) isExceeded(usage QuotaUsage, bytes int64, nodes int64) bool {
+	return (bytes > 0 && q.MaxBytes > 0 && usage.Bytes+bytes > q.MaxBytes) ||
+		(nodes > 0 && q.MaxNodes > 0 && usage.Nodes+nodes > q.MaxNodes)
+}
+
+/*
+Map화
+*/
+func (q ResourceQuota) ToMap() map[string]any {
+	return map[string]any{
+		"maxBytes": q.MaxBytes,
+		"maxNodes": q.MaxNodes,
+	}
+}
+
+/*
+map으로부터 저장 용량 제한을 생성
+*/
+func FromMapResourceQuota(quotaMap map[string]any) ResourceQuota {
+	quota := ResourceQuota{}
+	if maxBytes, ok := quotaMap["maxBytes"].(float64); ok {
+		quota.MaxBytes = int64(maxBytes)
+	}
+	if maxNodes, ok := quotaMap["maxNodes"].(float64); ok {
+		quota.MaxNodes = int64(maxNodes)
+	}
+	return quota
+}
+
+/*
+Map화
+*/
+func (u QuotaUsage) ToMap() map[string]any {
+	return map[string]any{
+		"bytes": u.Bytes,
+		"nodes": u.Nodes,
+	}
+}
+
+/*
+이름별 저장 용량 제한 map을 Map화
+*/
+func quotaMapToMap(quotaMap map[string]ResourceQuota) map[string]any {
+	result := map[string]any{}
+	for name, quota := range quotaMap {
+		result[name] = quota.ToMap()
+	}
+	return result
+}
+
+/*
+map으로부터 이름별 저장 용량 제한 map을 생성
+*/
+func fromMapQuotaMap(value any) map[string]ResourceQuota {
+	quotaMap := map[string]ResourceQuota{}
+	valueMap, _ := value.(map[string]any)
+	for name, quotaValue := range valueMap {
+		if quotaValueMap, ok := quotaValue.(map[string]any); ok {
+			quotaMap[name] = FromMapResourceQuota(quotaValueMap)
+		}
+	}
+	return quotaMap
+}
@@ -0,0 +1,87 @@
+package class
+
+import (
+	"time"
+)
+
+/*
+특정 시각의 리소스 트리 전체
+이름, 권한, 속성, 메타데이터, 내용의 해시를 보관하며 변경할 수 없음
+*/
+type ResourceSnapshot struct {
+	label     string
+	root      *ResourceObject
+	creator   string
+	createdAt time.Time
+}
+
+/*
+스냅샷 이름 반환
+*/
+func (s ResourceSnapshot) GetLabel() string {
+	return s.label
+}
+
+/*
+스냅샷을 만든 유저 반환
+*/
+func (s ResourceSnapshot) GetCreator() string {
+	return s.creator
+}
+
+/*
+스냅샷을 만든 시각 반환
+*/
+func (s ResourceSnapshot) GetCreatedAt() time.Time {
+	return s.createdAt
+}
+
+/*
+스냅샷의 전체 크기 반환
+*/
+func (s ResourceSnapshot) GetSize() int64 {
+	return s.root.GetSize()
+}
+
+/*
+스냅샷에서 특정 경로의 리소스 객체 반환
+반환한 리소스 객체는 변경하지 않아야 함
+*/
+func (s ResourceSnapshot) GetResourceObject(path string) *ResourceObject {
+	return getResourceObjectFrom(s.root, path)
+}
+
+/*
+Map화
+*/
+func (s ResourceSnapshot) ToMap() map[string]any {
+	return map[string]any{
+		"label":     s.label,
+		"root":      s.root.ToMap(),
+		"creator":   s.creator,
+		"createdAt": formatTime(s.createdAt),
+	}
+}
+
+/*
+map으로부터 스냅샷을 생성
+형식이 올바르지 않으면 false
+*/
+func FromMapResourceSnapshot(snapshotMap map[string]any) (ResourceSnapshot, bool) {
+	label, ok := snapshotMap["label"].(string)
+	if !ok || label == "" {
+		return ResourceSnapshot{}, false
+	}
+	rootMap, ok := snapshotMap["root"].(map[string]any)
+	if !ok {
+		return ResourceSnapshot{}, false
+	}
+	creator, _ := snapshotMap["creator"].(string)
+
+	return ResourceSnapshot{
+		label:     label,
+		root:      FromMapResourceObject(rootMap),
+		creator:   creator,
+		createdAt: parseTime(snapshotMap["createdAt"]),
+	}, true
+}
@@ -0,0 +1,138 @@
+package class
+
+import (
+	storage "app/storage"
+	"time"
+)
+
+/*
+파일의 이전 내용
+*/
+type ResourceVersion struct {
+	number      int64
+	contentHash string
+	size        int64
+	modifier    string
+	modifiedAt  time.Time
+}
+
+/*
+이전 버전 보관 정책
+*/
+type VersionPolicy struct {
+	// 파일마다 보관할 이전 버전의 최대 개수, 0이면 보관하지 않고 음수이면 제한 없음
+	MaxCount int
+	// 이전 버전을 보관할 기간, 0 이하이면 제한 없음
+	MaxAge time.Duration
+}
+
+// 기본 보관 정책
+var DefaultVersionPolicy = VersionPolicy{
+	MaxCount: 10,
+}
+
+/*
+버전 번호 반환
+파일에 내용을 쓸 때마다 1씩 증가
+*/
+func (v ResourceVersion) GetNumber() int64 {
+	return v.number
+}
+
+/*
+내용의 해시 반환
+*/
+func (v ResourceVersion) GetContentHash() string {
+	return v.contentHash
+}
+
+/*
+내용의 크기 반환
+*/
+func (v ResourceVersion) GetSize() int64 {
+	return v.size
+}
+
+/*
+이 버전의 내용을 쓴 유저 반환
+*/
+func (v ResourceVersion) GetModifier() string {
+	return v.modifier
+}
+
+/*
+이 버전의 내용을 쓴 시각 반환
+*/
+func (v ResourceVersion) GetModifiedAt() time.Time {
+	return v.modifiedAt
+}
+
+/*
+ETag 반환
+*/
+func (v ResourceVersion) GetETag() string {
+	return getContentETag(v.contentHash)
+}
+
+/*
+Map화
+*/
+func (v ResourceVersion) ToMap() map[string]any {
+	return map[string]any{
+		"number":      v.number,
+		"contentHash": v.contentHash,
+		"size":        v.size,
+		"modifier":    v.modifier,
+		"modifiedAt":  formatTime(v.modifiedAt),
+	}
+}
+
+/*
+map으로부터 버전을 생성
+형식이 올바르지 않으면 false
+*/
+func FromMapResourceVersion(versionMap map[string]any) (ResourceVersion, bool) {
+	number, ok := versionMap["number"].(float64)
+	if !ok {
+		return ResourceVersion{}, false
+	}
+	contentHash, ok := versionMap["contentHash"].(string)
+	if !ok || !storage.IsValidHash(contentHash) {
+		return ResourceVersion{}, false
+	}
+	size, _ := versionMap["size"].(float64)
+	modifier, _ := versionMap["modifier"].(string)
+
+	return ResourceVersion{
+		number:      int64(number),
+		contentHash: contentHash,
+		size:        int64(size),
+		modifier:    modifier,
+		modifiedAt:  parseTime(versionMap["modifiedAt"]),
+	}, true
+}
+
+/*
+Map화
+*/
+func (p VersionPolicy) ToMap() map[string]any {
+	return map[string]any{
+		"maxCount":      p.MaxCount,
+		"maxAgeSeconds": int64(p.MaxAge / time.Second),
+	}
+}
+
+/*
+map으로부터 보관 정책을 생성
+값이 없는 항목은 기본 정책의 값 사용
+*/
+func FromMapVersionPolicy(policyMap map[string]any) VersionPolicy {
+	policy := DefaultVersionPolicy
+	if maxCount, ok := policyMap["maxCount"].(float64); ok {
+		policy.MaxCount = int(maxCount)
+	}
+	if maxAgeSeconds, ok := policyMap["maxAgeSeconds"].(float64); ok {
+		policy.MaxAge = time.Duration(maxAgeSeconds) * time.Second
+	}
+	return policy
+}
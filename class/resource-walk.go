@@ -0,0 +1,217 @@
+package class
+
+import (
+	util "app/util"
+	"errors"
+	"regexp"
+	"time"
+)
+
+// WalkFunc가 반환하면 해당 리소스의 하위 리소스를 건너뜀
+var SkipSubtree = errors.New("하위 리소스를 건너뜁니다")
+
+/*
+Walk에서 리소스마다 호출하는 함수
+depth는 시작 리소스가 0
+SkipSubtree를 반환하면 하위 리소스를 건너뛰고, 그 외의 error를 반환하면 순회를 멈추고 해당 error 반환
+*/
+type WalkFunc func(resource *ResourceObject, depth int) error
+
+type WalkParam struct {
+	// 순회할 최대 깊이, 0 이하이면 제한 없음
+	MaxDepth int
+}
+
+/*
+리소스 검색 조건
+값이 없는 조건은 확인하지 않음
+*/
+type ResourceQuery struct {
+	// 검색을 시작할 경로, 비어있으면 "/"
+	Root string
+	// 검색할 최대 깊이, 0 이하이면 제한 없음
+	MaxDepth int
+	// 경로의 glob 패턴 (예시: `/foo/**/*.txt`)
+	PathGlob string
+	// 이름의 glob 패턴 (예시: `*.txt`)
+	NameGlob string
+	// RESOURCE_TYPE_FILE 또는 RESOURCE_TYPE_DIRECTORY
+	Type string
+	// 메타데이터 조건, false이면 제외
+	Predicate func(resource *ResourceObject) bool
+	// 해당 유저 또는 그룹이 Permission 권한을 가진 리소스만 검색
+	PermissionUser  string
+	PermissionGroup string
+	Permission      string
+	// false이면 하위 리소스와 함께 제외 (예시: 요청한 유저가 읽을 수 없는 리소스)
+	IsVisible func(resource *ResourceObject) bool
+	// 최대 결과 개수, 0 이하이면 제한 없음
+	Limit int
+}
+
+const (
+	RESOURCE_TYPE_FILE      = "file"
+	RESOURCE_TYPE_DIRECTORY = "directory"
+)
+
+// 결과 개수를 채워 검색을 멈출 때 사용
+var errQueryLimitReached = errors.New("검색 결과 개수 제한에 도달했습니다")
+
+/*
+경로의 리소스와 모든 하위 리소스를 전위 순회
+하위 리소스는 이름순으로 순회
+*/
+func (m *ResourceManager) Walk(root string, fn WalkFunc) error {
+	return m.WalkWithParam(root, WalkParam{}, fn)
+}
+
+/*
+경로의 리소스와 하위 리소스를 깊이 제한과 함께 전위 순회
+경로에 리소스가 없으면 ErrNotFound
+*/
+func (m *ResourceManager) WalkWithParam(root string, param WalkParam, fn WalkFunc) error {
+	m, unlock := m.rlockTree()
+	defer unlock()
+
+	resource := m.GetResourceObject(root)
+	if resource == nil {
+		return ErrNotFound
+	}
+
+	err := walkResourceObject(resource, 0, param, fn)
+	if errors.Is(err, SkipSubtree) {
+		return nil
+	}
+	return err
+}
+
+/*
+리소스와 하위 리소스를 전위 순회
+*/
+func walkResourceObject(resource *ResourceObject, depth int, param WalkParam, fn WalkFunc) error {
+	if err := fn(resource, depth); err != nil {
+		return err
+	}
+	if param.MaxDepth > 0 && depth >= param.MaxDepth {
+		return nil
+	}
+
+	for _, child := range resource.GetChildren() {
+		err := walkResourceObject(child, depth+1, param, fn)
+		if err != nil && !errors.Is(err, SkipSubtree) {
+			return err
+		}
+	}
+	return nil
+}
+
+/*
+조건에 맞는 리소스를 전위 순회 순서로 반환
+경로에 리소스가 없으면 ErrNotFound, glob 패턴이 올바르지 않으면 util.ErrInvalidGlob
+*/
+func (m *ResourceManager) Query(query ResourceQuery) ([]*ResourceObject, error) {
+	m, unlock := m.rlockTree()
+	defer unlock()
+
+	root := query.Root
+	if root == "" {
+		root = "/"
+	}
+	// glob 패턴은 리소스마다 변환하지 않도록 미리 변환
+	globs, err := query.compileGlobs()
+	if err != nil {
+		return nil, err
+	}
+
+	resources := []*ResourceObject{}
+	err = m.WalkWithParam(root, WalkParam{MaxDepth: query.MaxDepth}, func(resource *ResourceObject, depth int) error {
+		if query.IsVisible != nil && !query.IsVisible(resource) {
+			return SkipSubtree
+		}
+		if !query.matches(resource, globs) {
+			return nil
+		}
+
+		resources = append(resources, resource)
+		if query.Limit > 0 && len(resources) >= query.Limit {
+			return errQueryLimitReached
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errQueryLimitReached) {
+		return nil, err
+	}
+	return resources, nil
+}
+
+/*
+검색 조건의 변환한 glob 패턴, 조건이 없으면 nil
+*/
+type queryGlobs struct {
+	path *regexp.Regexp
+	name *regexp.Regexp
+}
+
+/*
+검색 조건의 glob 패턴 변환
+*/
+func (q ResourceQuery) compileGlobs() (queryGlobs, error) {
+	globs := queryGlobs{}
+	var err error
+	if q.PathGlob != "" {
+		if globs.path, err = util.CompileGlob(q.PathGlob); err != nil {
+			return globs, err
+		}
+	}
+	if q.NameGlob != "" {
+		if globs.name, err = util.CompileGlob(q.NameGlob); err != nil {
+			return globs, err
+		}
+	}
+	return globs, nil
+}
+
+/*
+리소스가 검색 조건에 맞는지 여부 반환
+*/
+func (q ResourceQuery) matches(resource *ResourceObject, globs queryGlobs) bool {
+	if globs.path != nil && !globs.path.MatchString(resource.GetPath()) {
+		return false
+	}
+	if globs.name != nil && !globs.name.MatchString(resource.GetName()) {
+		return false
+	}
+	if (q.Type == RESOURCE_TYPE_FILE && !resource.IsFile()) ||
+		(q.Type == RESOURCE_TYPE_DIRECTORY && !resource.IsDirectory()) {
+		return false
+	}
+	if q.Permission != "" && !resource.CheckPermission(q.PermissionUser, q.PermissionGroup, q.Permission) {
+		return false
+	}
+	if q.Predicate != nil && !q.Predicate(resource) {
+		return false
+	}
+	return true
+}
+
+/*
+수정 시각이 범위 안에 있는지 확인하는 메타데이터 조건 반환
+zero value인 시각은 확인하지 않음
+*/
+func ModifiedBetween(after time.Time, before time.Time) func(resource *ResourceObject) bool {
+	return func(resource *ResourceObject) bool {
+		return (after.IsZero() || resource.GetModifiedAt().After(after)) &&
+			(before.IsZero() || resource.GetModifiedAt().Before(before))
+	}
+}
+
+/*
+크기가 범위 안에 있는지 확인하는 메타데이터 조건 반환
+음수인 값은 확인하지 않음
+*/
+func SizeBetween(minSize int64, maxSize int64) func(resource *ResourceObject) bool {
+	return func(resource *ResourceObject) bool {
+		return (minSize < 0 || resource.GetSize() >= minSize) &&
+			(maxSize < 0 || resource.GetSize() <= maxSize)
+	}
+}
@@ -0,0 +1,141 @@
+package class
+
+import (
+	"time"
+)
+
+/*
+휴지통으로 옮겨진 리소스
+리소스의 권한, 속성, 이전 버전, 하위 리소스는 그대로 보관
+*/
+type TrashItem struct {
+	id           string
+	originalPath string
+	resource     *ResourceObject
+	deleter      string
+	deletedAt    time.Time
+}
+
+/*
+휴지통 보관 정책
+*/
+type TrashPolicy struct {
+	// false이면 삭제한 리소스를 휴지통에 보관하지 않고 바로 삭제
+	IsEnabled bool
+	// 휴지통에 보관할 기간, 0 이하이면 제한 없음
+	MaxAge time.Duration
+}
+
+// 기본 보관 정책
+var DefaultTrashPolicy = TrashPolicy{
+	IsEnabled: true,
+	MaxAge:    30 * 24 * time.Hour,
+}
+
+/*
+휴지통 항목의 id 반환
+*/
+func (t TrashItem) GetId() string {
+	return t.id
+}
+
+/*
+삭제되기 전의 경로 반환
+*/
+func (t TrashItem) GetOriginalPath() string {
+	return t.originalPath
+}
+
+/*
+삭제된 리소스 반환
+*/
+func (t TrashItem) GetResourceObject() *ResourceObject {
+	return t.resource
+}
+
+/*
+삭제한 유저 반환
+*/
+func (t TrashItem) GetDeleter() string {
+	return t.deleter
+}
+
+/*
+삭제한 시각 반환
+*/
+func (t TrashItem) GetDeletedAt() time.Time {
+	return t.deletedAt
+}
+
+/*
+해당 시각에 보관 기간이 지났는지 여부 반환
+*/
+func (t TrashItem) IsExpired(policy TrashPolicy, now time.Time) bool {
+	return policy.MaxAge > 0 && now.Sub(t.deletedAt) > policy.MaxAge
+}
+
+/*
+Map화
+*/
+func (t TrashItem) ToMap() map[string]any {
+	return map[string]any{
+		"id":           t.id,
+		"originalPath": t.originalPath,
+		"resource":     t.resource.ToMap(),
+		"deleter":      t.deleter,
+		"deletedAt":    formatTime(t.deletedAt),
+	}
+}
+
+/*
+map으로부터 휴지통 항목을 생성
+형식이 올바르지 않으면 false
+*/
+func FromMapTrashItem(trashItemMap map[string]any) (TrashItem, bool) {
+	id, ok := trashItemMap["id"].(string)
+	if !ok || id == "" {
+		return TrashItem{}, false
+	}
+	originalPath, ok := trashItemMap["originalPath"].(string)
+	if !ok {
+		return TrashItem{}, false
+	}
+	resourceMap, ok := trashItemMap["resource"].(map[string]any)
+	if !ok {
+		return TrashItem{}, false
+	}
+	deleter, _ := trashItemMap["deleter"].(string)
+
+	return TrashItem{
+		id:           id,
+		originalPath: originalPath,
+		resource:     FromMapResourceObject(resourceMap),
+		deleter:      deleter,
+		deletedAt:    parseTime(trashItemMap["deletedAt"]),
+	}, true
+}
+
+/*
+Map화
+*/
+func (p TrashPolicy) ToMap() map[string]any {
+	return map[string]any{
+		"isEnabled":     p.IsEnabled,
+		"maxAgeSeconds": int64(p.MaxAge / time.Second),
+	}
+}
+
+/*
+map으로부터 보관 정책을 생성
+값이 없는 항목은 기본 정책의 값 사용
+*/
+func FromMapTrashPolicy(policyMap map[string]any) TrashPolicy {
+	policy := DefaultTrashPolicy
+	if isEnabled, ok := policyMap["isEnabled"].(bool); ok {
+		policy.IsEnabled = isEnabled
+	}
+	if maxAgeSeconds, ok := policyMap["maxAgeSeconds"].(float64); ok {
+		policy.MaxAge = time.Duration(maxAgeSeconds) * time.Second
+	}
+	return policy
+}
@@ -0,0 +1,190 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+)
+
+/*
+하위 명령어
+  - run: 위치 인자를 받아 실행하고 종료 코드 반환
+*/
+type command struct {
+	name        string
+	usage       string
+	description string
+	run         func(env *environment, args []string) int
+}
+
+/*
+명령어 실행 환경
+*/
+type environment struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+var commands []command
+
+func init() {
+	commands = []command{
+		{"serve", "serve [--config 파일] [--data 파일] [--listen 주소] [--tls-cert 파일 --tls-key 파일]", "서버 시작", runServe},
+		{"tree", "tree [경로] [--depth 깊이]", "리소스 트리 출력", runTree},
+		{"stat", "stat <경로>", "리소스 메타데이터 출력", runStat},
+		{"mkdir", "mkdir [-p] <경로>", "폴더 생성", runMkdir},
+		{"rm", "rm <경로>", "리소스 삭제 (휴지통을 사용하면 휴지통으로 이동)", runRemove},
+		{"mv", "mv [--overwrite] <경로> <이동할 경로>", "리소스 이동", runMove},
+		{"grant", "grant <경로> <user:이름|group:이름> <권한>", "권한 추가", runGrant},
+		{"revoke", "revoke <경로> <user:이름|group:이름> <권한>", "권한 삭제", runRevoke},
+		{"locks", "locks [--owner 유저]", "잠금 목록 출력", runLocks},
+		{"unlock", "unlock <경로> --token 토큰 | unlock --force [경로] [--owner 유저]", "잠금 해제", runUnlock},
+		{"export", "export [경로] [--output 파일]", "리소스를 JSON으로 내보내기", runExport},
+		{"import", "import <경로> [--input 파일] [--overwrite]", "내보낸 JSON을 경로로 가져오기", runImport},
+	}
+}
+
+/*
+명령줄 인자로 하위 명령어 실행
+  - @param {[]string} args 프로그램 이름을 제외한 인자 (예시: os.Args[1:])
+  - @return {int} 종료 코드, 성공하면 0, 실행 중 실패하면 1, 인자가 올바르지 않으면 2
+*/
+func Run(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	env := &environment{stdin: stdin, stdout: stdout, stderr: stderr}
+	if len(args) == 0 {
+		writeUsage(stderr)
+		return 2
+	}
+	if args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		writeUsage(stdout)
+		return 0
+	}
+
+	for _, command := range commands {
+		if command.name == args[0] {
+			return command.run(env, args[1:])
+		}
+	}
+	fmt.Fprintf(stderr, "알 수 없는 명령어입니다: %s\n\n", args[0])
+	writeUsage(stderr)
+	return 2
+}
+
+func writeUsage(output io.Writer) {
+	fmt.Fprintln(output, "사용법: <명령어> [인자] [--data 파일 | --server URL] [--user 유저] [--group 그룹] [--lock-token 토큰]")
+	fmt.Fprintln(output)
+	fmt.Fprintln(output, "명령어:")
+	for _, command := range commands {
+		fmt.Fprintf(output, "  %-10s %s\n", command.name, command.description)
+		fmt.Fprintf(output, "  %-10s   %s\n", "", command.usage)
+	}
+	fmt.Fprintln(output)
+	fmt.Fprintln(output, "serve 외의 명령어는 --data로 데이터 파일을 직접 수정하거나 --server로 실행 중인 서버에 요청합니다.")
+	fmt.Fprintln(output, "데이터 파일을 직접 수정할 때는 같은 파일을 사용하는 서버를 멈춘 후 사용하세요.")
+}
+
+/*
+데이터 파일 또는 서버에 접근하기 위한 공통 옵션
+*/
+type clientOptions struct {
+	dataPath   string
+	serverUrl  string
+	username   string
+	groupname  string
+	lockTokens stringList
+}
+
+/*
+여러 번 지정할 수 있는 문자열 옵션
+*/
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+/*
+하위 명령어의 FlagSet 생성
+*/
+func newFlagSet(env *environment, name string) *flag.FlagSet {
+	flagSet := flag.NewFlagSet(name, flag.ContinueOnError)
+	flagSet.SetOutput(env.stderr)
+	return flagSet
+}
+
+/*
+FlagSet에 client 공통 옵션 등록
+*/
+func addClientFlags(flagSet *flag.FlagSet) *clientOptions {
+	options := &clientOptions{}
+	flagSet.StringVar(&options.dataPath, "data", "", "직접 수정할 데이터 파일 경로")
+	flagSet.StringVar(&options.serverUrl, "server", "", "요청할 서버 주소 (예시: http://localhost:3000)")
+	flagSet.StringVar(&options.username, "user", "", "작업하는 유저, 서버에는 User-Name 헤더로 전송")
+	flagSet.StringVar(&options.groupname, "group", "", "작업하는 그룹, 서버에는 Group-Name 헤더로 전송")
+	flagSet.Var(&options.lockTokens, "lock-token", "잠긴 리소스를 변경할 때 제출할 잠금 토큰, 여러 번 지정 가능")
+	return options
+}
+
+/*
+옵션에 해당하는 client 생성
+--data와 --server 중 하나만 지정해야 함
+*/
+func (o clientOptions) open() (client, error) {
+	switch {
+	case o.dataPath != "" && o.serverUrl != "":
+		return nil, errors.New("--data와 --server는 함께 지정할 수 없습니다")
+	case o.dataPath != "":
+		return newLocalClient(o.dataPath, o.username, o.groupname, o.lockTokens)
+	case o.serverUrl != "":
+		return newRemoteClient(o.serverUrl, o.username, o.groupname, o.lockTokens)
+	default:
+		return nil, errors.New("--data 또는 --server를 지정해야 합니다")
+	}
+}
+
+/*
+옵션과 위치 인자가 섞인 인자를 파싱하여 위치 인자 반환
+"--" 이후의 인자는 모두 위치 인자로 처리
+*/
+func parseArgs(flagSet *flag.FlagSet, args []string) ([]string, error) {
+	positionalArgs := []string{}
+	for {
+		if err := flagSet.Parse(args); err != nil {
+			return nil, err
+		}
+		remainingArgs := flagSet.Args()
+		if len(remainingArgs) == 0 {
+			return positionalArgs, nil
+		}
+		if len(args) > len(remainingArgs) && args[len(args)-len(remainingArgs)-1] == "--" {
+			return append(positionalArgs, remainingArgs...), nil
+		}
+		positionalArgs = append(positionalArgs, remainingArgs[0])
+		args = remainingArgs[1:]
+	}
+}
+
+/*
+인자 오류를 출력하고 종료 코드 2 반환
+*/
+func usageError(env *environment, flagSet *flag.FlagSet, usage string) int {
+	fmt.Fprintf(env.stderr, "사용법: %s\n", usage)
+	flagSet.PrintDefaults()
+	return 2
+}
+
+/*
+실행 중 오류를 출력하고 종료 코드 1 반환
+*/
+func runError(env *environment, err error) int {
+	fmt.Fprintf(env.stderr, "오류: %v\n", err)
+	return 1
+}
@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var (
+	// 요청한 잠금이 없거나 잠금 토큰이 일치하지 않는 경우
+	ErrLockNotFound = errors.New("잠금이 없거나 잠금 토큰이 일치하지 않습니다")
+	// 서버에서 리소스를 찾을 수 없는 경우, 읽을 권한이 없어도 찾을 수 없음
+	ErrNotVisible = errors.New("리소스가 없거나 읽을 권한이 없습니다")
+)
+
+/*
+리소스 트리를 조회, 변경하는 방법
+데이터 파일을 직접 수정하는 localClient와 실행 중인 서버에 요청하는 remoteClient가 있음
+*/
+type client interface {
+	// 경로의 리소스와 하위 리소스를 전위 순회 순서로 반환, depth가 0 이하이면 제한 없음
+	tree(path string, depth int) ([]resourceEntry, error)
+	stat(path string) (resourceEntry, error)
+	// isParents가 true이면 없는 상위 폴더도 생성하며, 이미 폴더가 있어도 실패하지 않음
+	mkdir(path string, isParents bool) error
+	remove(path string) error
+	move(sourcePath string, destinationPath string, isOverwrite bool) error
+	// principalType은 class.PRINCIPAL_TYPE_USER 또는 class.PRINCIPAL_TYPE_GROUP
+	grant(path string, principalType string, name string, permission string) ([]string, error)
+	revoke(path string, principalType string, name string, permission string) error
+	// owner가 비어있지 않으면 해당 유저가 소유한 잠금만 반환
+	locks(owner string) ([]lockEntry, error)
+	unlock(path string, lockToken string) error
+	// path가 비어있으면 트리 전체에서 owner의 잠금을 해제, 둘 다 비어있으면 모든 잠금 해제
+	unlockForce(path string, owner string) ([]lockEntry, error)
+	export(path string) (map[string]any, error)
+	// 경로의 리소스를 덮어썼으면 true
+	importResource(path string, exportMap map[string]any, isOverwrite bool) (bool, error)
+	// 변경 사항을 저장하고 정리
+	close() error
+}
+
+/*
+리소스 메타데이터
+/_search 응답의 항목과 같은 형식
+*/
+type resourceEntry struct {
+	Path         string `json:"path"`
+	Name         string `json:"name"`
+	IsDirectory  bool   `json:"isDirectory"`
+	Size         int64  `json:"size"`
+	CreatedAt    string `json:"createdAt"`
+	ModifiedAt   string `json:"modifiedAt"`
+	Creator      string `json:"creator"`
+	LastModifier string `json:"lastModifier"`
+}
+
+/*
+잠금 정보
+/_admin/locks 응답의 항목과 같은 형식
+*/
+type lockEntry struct {
+	Path      string `json:"path"`
+	Owner     string `json:"owner"`
+	Depth     string `json:"depth"`
+	Scope     string `json:"scope"`
+	ExpiresAt string `json:"expiresAt"`
+	Token     string `json:"token"`
+}
+
+/*
+서버가 실패 응답 코드로 응답한 경우
+*/
+type statusError struct {
+	method     string
+	path       string
+	statusCode int
+}
+
+func (e statusError) Error() string {
+	return fmt.Sprintf("%s %s: %d %s", e.method, e.path, e.statusCode, http.StatusText(e.statusCode))
+}
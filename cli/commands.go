@@ -0,0 +1,309 @@
+package cli
+
+import (
+	"app/class"
+	"app/constant"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+)
+
+/*
+명령어의 옵션과 위치 인자를 파싱하고 client를 연 후 fn 실행
+위치 인자 개수가 minArgs 이상 maxArgs 이하가 아니면 사용법 출력
+client는 fn이 성공하면 닫으며 저장에 실패하면 오류로 처리
+*/
+func runWithClient(env *environment, flagSet *flag.FlagSet, usage string, args []string, minArgs int, maxArgs int, fn func(c client, args []string) error) int {
+	options := addClientFlags(flagSet)
+	positionalArgs, err := parseArgs(flagSet, args)
+	if err != nil {
+		return getParseErrorCode(err)
+	}
+	if len(positionalArgs) < minArgs || len(positionalArgs) > maxArgs {
+		return usageError(env, flagSet, usage)
+	}
+
+	c, err := options.open()
+	if err != nil {
+		return runError(env, err)
+	}
+	if err := fn(c, positionalArgs); err != nil {
+		c.close()
+		return runError(env, err)
+	}
+	if err := c.close(); err != nil {
+		return runError(env, fmt.Errorf("데이터 파일 저장에 실패했습니다: %w", err))
+	}
+	return 0
+}
+
+/*
+옵션 파싱 오류의 종료 코드 반환
+-h, --help이면 0
+*/
+func getParseErrorCode(err error) int {
+	if errors.Is(err, flag.ErrHelp) {
+		return 0
+	}
+	return 2
+}
+
+func runTree(env *environment, args []string) int {
+	flagSet := newFlagSet(env, "tree")
+	depth := flagSet.Int("depth", 0, "출력할 최대 깊이, 0이면 제한 없음")
+	return runWithClient(env, flagSet, commandUsage("tree"), args, 0, 1, func(c client, args []string) error {
+		path := "/"
+		if len(args) == 1 {
+			path = args[0]
+		}
+		entries, err := c.tree(path, *depth)
+		if err != nil {
+			return err
+		}
+
+		rootDepth := strings.Count(entries[0].Path, "/")
+		if entries[0].Path == "/" {
+			rootDepth = 0
+		}
+		for i, entry := range entries {
+			name := entry.Name
+			indent := strings.Repeat("  ", strings.Count(entry.Path, "/")-rootDepth)
+			if i == 0 {
+				name = entry.Path
+				indent = ""
+			}
+			if entry.IsDirectory {
+				if !strings.HasSuffix(name, "/") {
+					name += "/"
+				}
+				fmt.Fprintf(env.stdout, "%s%s\n", indent, name)
+			} else {
+				fmt.Fprintf(env.stdout, "%s%s (%d B)\n", indent, name, entry.Size)
+			}
+		}
+		return nil
+	})
+}
+
+func runStat(env *environment, args []string) int {
+	flagSet := newFlagSet(env, "stat")
+	return runWithClient(env, flagSet, commandUsage("stat"), args, 1, 1, func(c client, args []string) error {
+		entry, err := c.stat(args[0])
+		if err != nil {
+			return err
+		}
+		resourceType := class.RESOURCE_TYPE_FILE
+		if entry.IsDirectory {
+			resourceType = class.RESOURCE_TYPE_DIRECTORY
+		}
+
+		writer := tabwriter.NewWriter(env.stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(writer, "path:\t%s\n", entry.Path)
+		fmt.Fprintf(writer, "type:\t%s\n", resourceType)
+		fmt.Fprintf(writer, "size:\t%d\n", entry.Size)
+		fmt.Fprintf(writer, "createdAt:\t%s\n", entry.CreatedAt)
+		fmt.Fprintf(writer, "modifiedAt:\t%s\n", entry.ModifiedAt)
+		fmt.Fprintf(writer, "creator:\t%s\n", entry.Creator)
+		fmt.Fprintf(writer, "lastModifier:\t%s\n", entry.LastModifier)
+		return writer.Flush()
+	})
+}
+
+func runMkdir(env *environment, args []string) int {
+	flagSet := newFlagSet(env, "mkdir")
+	isParents := flagSet.Bool("p", false, "없는 상위 폴더도 생성하며, 이미 폴더가 있어도 실패하지 않음")
+	return runWithClient(env, flagSet, commandUsage("mkdir"), args, 1, 1, func(c client, args []string) error {
+		return c.mkdir(args[0], *isParents)
+	})
+}
+
+func runRemove(env *environment, args []string) int {
+	flagSet := newFlagSet(env, "rm")
+	return runWithClient(env, flagSet, commandUsage("rm"), args, 1, 1, func(c client, args []string) error {
+		return c.remove(args[0])
+	})
+}
+
+func runMove(env *environment, args []string) int {
+	flagSet := newFlagSet(env, "mv")
+	isOverwrite := flagSet.Bool("overwrite", false, "이동할 경로에 리소스가 있으면 덮어씀")
+	return runWithClient(env, flagSet, commandUsage("mv"), args, 2, 2, func(c client, args []string) error {
+		return c.move(args[0], args[1], *isOverwrite)
+	})
+}
+
+func runGrant(env *environment, args []string) int {
+	flagSet := newFlagSet(env, "grant")
+	return runWithClient(env, flagSet, commandUsage("grant"), args, 3, 3, func(c client, args []string) error {
+		principalType, name, err := parsePermissionArgs(args[1], args[2])
+		if err != nil {
+			return err
+		}
+		permissions, err := c.grant(args[0], principalType, name, args[2])
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(env.stdout, "%s %s: %s\n", principalType, name, strings.Join(permissions, ", "))
+		return nil
+	})
+}
+
+func runRevoke(env *environment, args []string) int {
+	flagSet := newFlagSet(env, "revoke")
+	return runWithClient(env, flagSet, commandUsage("revoke"), args, 3, 3, func(c client, args []string) error {
+		principalType, name, err := parsePermissionArgs(args[1], args[2])
+		if err != nil {
+			return err
+		}
+		return c.revoke(args[0], principalType, name, args[2])
+	})
+}
+
+/*
+`user:이름`, `group:이름` 형식의 권한 대상과 권한 이름 확인
+*/
+func parsePermissionArgs(principal string, permission string) (string, string, error) {
+	principalType, name, ok := strings.Cut(principal, ":")
+	if !ok || name == "" || (principalType != class.PRINCIPAL_TYPE_USER && principalType != class.PRINCIPAL_TYPE_GROUP) {
+		return "", "", fmt.Errorf("권한 대상은 user:이름 또는 group:이름 형식이어야 합니다: %s", principal)
+	}
+	if !slices.Contains(constant.PERMISSIONS[:], permission) {
+		return "", "", fmt.Errorf("알 수 없는 권한입니다: %s (%s)", permission, strings.Join(constant.PERMISSIONS[:], ", "))
+	}
+	return principalType, name, nil
+}
+
+func runLocks(env *environment, args []string) int {
+	flagSet := newFlagSet(env, "locks")
+	owner := flagSet.String("owner", "", "해당 유저가 소유한 잠금만 출력")
+	return runWithClient(env, flagSet, commandUsage("locks"), args, 0, 0, func(c client, args []string) error {
+		locks, err := c.locks(*owner)
+		if err != nil {
+			return err
+		}
+		return writeLocks(env.stdout, locks)
+	})
+}
+
+func runUnlock(env *environment, args []string) int {
+	flagSet := newFlagSet(env, "unlock")
+	lockToken := flagSet.String("token", "", "해제할 잠금의 토큰")
+	isForce := flagSet.Bool("force", false, "토큰 없이 강제 해제, 경로가 없으면 트리 전체의 잠금 해제")
+	owner := flagSet.String("owner", "", "--force와 함께 지정하면 해당 유저가 소유한 잠금만 해제")
+	return runWithClient(env, flagSet, commandUsage("unlock"), args, 0, 1, func(c client, args []string) error {
+		path := ""
+		if len(args) == 1 {
+			path = args[0]
+		}
+
+		switch {
+		case *isForce && *lockToken != "":
+			return errors.New("--force와 --token은 함께 지정할 수 없습니다")
+		case *isForce && path != "" && *owner != "":
+			return errors.New("경로를 지정하면 --owner는 지정할 수 없습니다")
+		case *isForce:
+			locks, err := c.unlockForce(path, *owner)
+			if err != nil {
+				return err
+			}
+			return writeLocks(env.stdout, locks)
+		case path == "" || *lockToken == "":
+			return errors.New("경로와 --token을 지정하거나 --force를 지정해야 합니다")
+		default:
+			return c.unlock(path, strings.TrimPrefix(*lockToken, "opaquelocktoken:"))
+		}
+	})
+}
+
+/*
+잠금 목록을 표 형식으로 출력
+*/
+func writeLocks(output io.Writer, locks []lockEntry) error {
+	writer := tabwriter.NewWriter(output, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "PATH\tOWNER\tDEPTH\tSCOPE\tEXPIRES\tTOKEN")
+	for _, lock := range locks {
+		expiresAt := lock.ExpiresAt
+		if expiresAt == "" {
+			expiresAt = "-"
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\n", lock.Path, lock.Owner, lock.Depth, lock.Scope, expiresAt, lock.Token)
+	}
+	return writer.Flush()
+}
+
+func runExport(env *environment, args []string) int {
+	flagSet := newFlagSet(env, "export")
+	outputPath := flagSet.String("output", "", "저장할 파일 경로, 비어있으면 표준 출력")
+	return runWithClient(env, flagSet, commandUsage("export"), args, 0, 1, func(c client, args []string) error {
+		path := "/"
+		if len(args) == 1 {
+			path = args[0]
+		}
+		exportMap, err := c.export(path)
+		if err != nil {
+			return err
+		}
+		data, err := json.MarshalIndent(exportMap, "", "  ")
+		if err != nil {
+			return err
+		}
+		data = append(data, '\n')
+
+		if *outputPath == "" {
+			_, err = env.stdout.Write(data)
+			return err
+		}
+		return writeFileAtomic(*outputPath, data)
+	})
+}
+
+func runImport(env *environment, args []string) int {
+	flagSet := newFlagSet(env, "import")
+	inputPath := flagSet.String("input", "", "가져올 파일 경로, 비어있으면 표준 입력")
+	isOverwrite := flagSet.Bool("overwrite", false, "경로에 리소스가 있으면 덮어씀")
+	return runWithClient(env, flagSet, commandUsage("import"), args, 1, 1, func(c client, args []string) error {
+		var data []byte
+		var err error
+		if *inputPath == "" {
+			data, err = io.ReadAll(env.stdin)
+		} else {
+			data, err = os.ReadFile(*inputPath)
+		}
+		if err != nil {
+			return err
+		}
+		var exportMap map[string]any
+		if err := json.Unmarshal(data, &exportMap); err != nil {
+			return class.ErrInvalidData
+		}
+
+		isOverwritten, err := c.importResource(args[0], exportMap, *isOverwrite)
+		if err != nil {
+			return err
+		}
+		if isOverwritten {
+			fmt.Fprintf(env.stdout, "%s: 덮어씀\n", args[0])
+		} else {
+			fmt.Fprintf(env.stdout, "%s: 생성함\n", args[0])
+		}
+		return nil
+	})
+}
+
+/*
+명령어 이름에 해당하는 사용법 반환
+*/
+func commandUsage(name string) string {
+	for _, command := range commands {
+		if command.name == name {
+			return command.usage
+		}
+	}
+	return name
+}
@@ -0,0 +1,254 @@
+package cli
+
+import (
+	"app/class"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+/*
+데이터 파일을 직접 불러와 수정하는 client
+서버가 같은 데이터 파일을 사용 중이면 서버가 저장할 때 변경 사항을 덮어쓰므로, 서버를 멈춘 후 사용
+*/
+type localClient struct {
+	dataPath        string
+	resourceManager *class.ResourceManager
+	username        string
+	groupname       string
+	lockTokens      []string
+	isModified      bool
+}
+
+/*
+데이터 파일을 불러와 localClient 생성
+파일이 없거나 형식이 올바르지 않으면 error 반환
+*/
+func newLocalClient(dataPath string, username string, groupname string, lockTokens []string) (*localClient, error) {
+	jsonData, err := os.ReadFile(dataPath)
+	if err != nil {
+		return nil, err
+	}
+	resourceManager, err := class.ParseJsonResourceManager(string(jsonData))
+	if err != nil {
+		return nil, err
+	}
+	return &localClient{
+		dataPath:        dataPath,
+		resourceManager: resourceManager,
+		username:        username,
+		groupname:       groupname,
+		lockTokens:      lockTokens,
+	}, nil
+}
+
+func (c *localClient) tree(path string, depth int) ([]resourceEntry, error) {
+	entries := []resourceEntry{}
+	err := c.resourceManager.WalkWithParam(path, class.WalkParam{MaxDepth: depth}, func(resource *class.ResourceObject, depth int) error {
+		entries = append(entries, toResourceEntry(resource))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (c *localClient) stat(path string) (resourceEntry, error) {
+	entry := resourceEntry{}
+	isExist := false
+	c.resourceManager.View(func(m *class.ResourceManager) {
+		if resource := m.GetResourceObject(path); resource != nil {
+			entry = toResourceEntry(resource)
+			isExist = true
+		}
+	})
+	if !isExist {
+		return resourceEntry{}, class.ErrNotFound
+	}
+	return entry, nil
+}
+
+func (c *localClient) mkdir(path string, isParents bool) error {
+	if isParents {
+		isDirectory := false
+		c.resourceManager.View(func(m *class.ResourceManager) {
+			resource := m.GetResourceObject(path)
+			isDirectory = resource != nil && resource.IsDirectory()
+		})
+		if isDirectory {
+			return nil
+		}
+	}
+	_, err := c.resourceManager.CreateResourceWithLockTokens(path, true, !isParents, c.username, c.groupname, c.lockTokens)
+	return c.modified(err)
+}
+
+func (c *localClient) remove(path string) error {
+	return c.modified(c.resourceManager.DeleteResourceWithLockTokens(path, c.username, c.lockTokens))
+}
+
+func (c *localClient) move(sourcePath string, destinationPath string, isOverwrite bool) error {
+	_, err := c.resourceManager.MoveResourceWithLockTokens(sourcePath, destinationPath, isOverwrite, c.username, c.lockTokens)
+	return c.modified(err)
+}
+
+func (c *localClient) grant(path string, principalType string, name string, permission string) ([]string, error) {
+	var permissions []string
+	var err error
+	if principalType == class.PRINCIPAL_TYPE_USER {
+		permissions, err = c.resourceManager.AddUserPermissionWithLockTokens(path, name, permission, c.username, c.lockTokens)
+	} else {
+		permissions, err = c.resourceManager.AddGroupPermissionWithLockTokens(path, name, permission, c.username, c.lockTokens)
+	}
+	return permissions, c.modified(err)
+}
+
+func (c *localClient) revoke(path string, principalType string, name string, permission string) error {
+	if principalType == class.PRINCIPAL_TYPE_USER {
+		return c.modified(c.resourceManager.DeleteUserPermissionWithLockTokens(path, name, permission, c.username, c.lockTokens))
+	}
+	return c.modified(c.resourceManager.DeleteGroupPermissionWithLockTokens(path, name, permission, c.username, c.lockTokens))
+}
+
+func (c *localClient) locks(owner string) ([]lockEntry, error) {
+	return toLockEntries(c.resourceManager.GetActiveLocks(owner)), nil
+}
+
+func (c *localClient) unlock(path string, lockToken string) error {
+	isExist := false
+	c.resourceManager.View(func(m *class.ResourceManager) {
+		isExist = m.GetResourceObject(path) != nil
+	})
+	if !isExist {
+		return class.ErrNotFound
+	}
+	if !c.resourceManager.Unlock(path, lockToken) {
+		return ErrLockNotFound
+	}
+	return c.modified(nil)
+}
+
+func (c *localClient) unlockForce(path string, owner string) ([]lockEntry, error) {
+	if path == "" {
+		locks := c.resourceManager.UnlockForceAll(owner)
+		if len(locks) > 0 {
+			c.isModified = true
+		}
+		return toLockEntries(locks), nil
+	}
+
+	locks, err := c.resourceManager.UnlockForceWithResult(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(locks) > 0 {
+		c.isModified = true
+	}
+	return toLockEntries(locks), nil
+}
+
+func (c *localClient) export(path string) (map[string]any, error) {
+	return c.resourceManager.ExportResource(path)
+}
+
+func (c *localClient) importResource(path string, exportMap map[string]any, isOverwrite bool) (bool, error) {
+	isOverwritten, err := c.resourceManager.ImportResourceWithLockTokens(path, exportMap, isOverwrite, c.username, c.lockTokens)
+	return isOverwritten, c.modified(err)
+}
+
+/*
+변경 사항이 있으면 데이터 파일에 저장
+임시 파일에 쓴 후 이름을 바꾸므로 저장 중 실패해도 기존 파일은 유지됨
+*/
+func (c *localClient) close() error {
+	if !c.isModified {
+		return nil
+	}
+	jsonData, err := c.resourceManager.ToJson()
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(c.dataPath, []byte(jsonData))
+}
+
+/*
+작업이 성공했으면 저장이 필요하다고 표시
+*/
+func (c *localClient) modified(err error) error {
+	if err == nil {
+		c.isModified = true
+	}
+	return err
+}
+
+/*
+같은 폴더의 임시 파일에 쓴 후 이름을 바꾸어 파일을 교체
+기존 파일이 있으면 권한을 유지
+*/
+func writeFileAtomic(path string, data []byte) error {
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tempPath := file.Name()
+	err = file.Chmod(mode)
+	if err == nil {
+		_, err = file.Write(data)
+	}
+	if err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tempPath, path)
+	}
+	if err != nil {
+		return errors.Join(err, os.Remove(tempPath))
+	}
+	return nil
+}
+
+func toResourceEntry(resource *class.ResourceObject) resourceEntry {
+	return resourceEntry{
+		Path:         resource.GetPath(),
+		Name:         resource.GetName(),
+		IsDirectory:  resource.IsDirectory(),
+		Size:         resource.GetSize(),
+		CreatedAt:    resource.GetCreatedAt().UTC().Format(time.RFC3339),
+		ModifiedAt:   resource.GetModifiedAt().UTC().Format(time.RFC3339),
+		Creator:      resource.GetCreator(),
+		LastModifier: resource.GetLastModifier(),
+	}
+}
+
+func toLockEntries(locks []class.ResourceLock) []lockEntry {
+	entries := []lockEntry{}
+	for _, lock := range locks {
+		depth := "0"
+		if lock.IsDepthInfinity() {
+			depth = "infinity"
+		}
+		expiresAt := ""
+		if !lock.GetExpiresAt().IsZero() {
+			expiresAt = lock.GetExpiresAt().UTC().Format(time.RFC3339)
+		}
+		entries = append(entries, lockEntry{
+			Path:      lock.GetRoot(),
+			Owner:     lock.GetOwner(),
+			Depth:     depth,
+			Scope:     lock.GetScope(),
+			ExpiresAt: expiresAt,
+			Token:     lock.GetToken(),
+		})
+	}
+	return entries
+}
@@ -0,0 +1,274 @@
+package cli
+
+import (
+	"app/class"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/*
+실행 중인 서버에 요청하는 client
+요청마다 User-Name, Group-Name 헤더를 보내므로 서버의 권한 확인을 거침
+*/
+type remoteClient struct {
+	baseUrl    *url.URL
+	httpClient *http.Client
+	username   string
+	groupname  string
+	lockTokens []string
+}
+
+/*
+서버 주소로 remoteClient 생성
+주소가 http 또는 https URL이 아니면 error 반환
+*/
+func newRemoteClient(serverUrl string, username string, groupname string, lockTokens []string) (*remoteClient, error) {
+	baseUrl, err := url.Parse(strings.TrimSuffix(serverUrl, "/"))
+	if err != nil || (baseUrl.Scheme != "http" && baseUrl.Scheme != "https") || baseUrl.Host == "" {
+		return nil, fmt.Errorf("서버 주소는 http 또는 https URL이어야 합니다: %s", serverUrl)
+	}
+	return &remoteClient{
+		baseUrl:    baseUrl,
+		httpClient: &http.Client{Timeout: time.Minute},
+		username:   username,
+		groupname:  groupname,
+		lockTokens: lockTokens,
+	}, nil
+}
+
+func (c *remoteClient) tree(path string, depth int) ([]resourceEntry, error) {
+	query := url.Values{"root": {path}}
+	if depth > 0 {
+		query.Set("depth", strconv.Itoa(depth))
+	}
+	var body struct {
+		Results []resourceEntry `json:"results"`
+	}
+	if err := c.requestJson("GET", "/_search", query, nil, nil, &body, 200); err != nil {
+		return nil, err
+	}
+	// 읽을 수 없거나 없는 경로는 결과가 비어있음
+	if len(body.Results) == 0 {
+		return nil, ErrNotVisible
+	}
+	return body.Results, nil
+}
+
+func (c *remoteClient) stat(path string) (resourceEntry, error) {
+	query := url.Values{"root": {path}, "depth": {"1"}, "limit": {"1"}}
+	var body struct {
+		Results []resourceEntry `json:"results"`
+	}
+	if err := c.requestJson("GET", "/_search", query, nil, nil, &body, 200); err != nil {
+		return resourceEntry{}, err
+	}
+	if len(body.Results) == 0 {
+		return resourceEntry{}, ErrNotVisible
+	}
+	return body.Results[0], nil
+}
+
+func (c *remoteClient) mkdir(path string, isParents bool) error {
+	if !isParents {
+		return c.requestJson("MKCOL", path, nil, nil, nil, nil, 201)
+	}
+
+	// 루트부터 차례로 생성하며 이미 있는 폴더(405)는 건너뜀
+	names := strings.Split(strings.Trim(path, "/"), "/")
+	for i := range names {
+		err := c.requestJson("MKCOL", "/"+strings.Join(names[:i+1], "/"), nil, nil, nil, nil, 201)
+		var statusErr statusError
+		if errors.As(err, &statusErr) && statusErr.statusCode == 405 {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	entry, err := c.stat(path)
+	if err != nil {
+		return err
+	}
+	if !entry.IsDirectory {
+		return class.ErrAlreadyExists
+	}
+	return nil
+}
+
+func (c *remoteClient) remove(path string) error {
+	return c.requestJson("DELETE", path, nil, nil, nil, nil, 204)
+}
+
+func (c *remoteClient) move(sourcePath string, destinationPath string, isOverwrite bool) error {
+	header := http.Header{}
+	header.Set("Destination", (&url.URL{Path: destinationPath}).EscapedPath())
+	header.Set("Overwrite", getOverwriteHeader(isOverwrite))
+	return c.requestJson("MOVE", sourcePath, nil, header, nil, nil, 201, 204)
+}
+
+func (c *remoteClient) grant(path string, principalType string, name string, permission string) ([]string, error) {
+	var body struct {
+		Permissions []string `json:"permissions"`
+	}
+	query := url.Values{"path": {path}, principalType: {name}, "permission": {permission}}
+	if err := c.requestJson("PUT", "/_admin/permissions/entries", query, nil, nil, &body, 200); err != nil {
+		return nil, err
+	}
+	return body.Permissions, nil
+}
+
+func (c *remoteClient) revoke(path string, principalType string, name string, permission string) error {
+	query := url.Values{"path": {path}, principalType: {name}, "permission": {permission}}
+	return c.requestJson("DELETE", "/_admin/permissions/entries", query, nil, nil, nil, 204)
+}
+
+func (c *remoteClient) locks(owner string) ([]lockEntry, error) {
+	var body struct {
+		Locks []lockEntry `json:"locks"`
+	}
+	if err := c.requestJson("GET", "/_admin/locks", getOwnerQuery(owner), nil, nil, &body, 200); err != nil {
+		return nil, err
+	}
+	return body.Locks, nil
+}
+
+func (c *remoteClient) unlock(path string, lockToken string) error {
+	header := http.Header{}
+	header.Set("Lock-Token", "<opaquelocktoken:"+lockToken+">")
+	err := c.requestJson("UNLOCK", path, nil, header, nil, nil, 204)
+	var statusErr statusError
+	if errors.As(err, &statusErr) && statusErr.statusCode == 409 {
+		return ErrLockNotFound
+	}
+	return err
+}
+
+func (c *remoteClient) unlockForce(path string, owner string) ([]lockEntry, error) {
+	query := getOwnerQuery(owner)
+	if path != "" {
+		query = url.Values{"path": {path}}
+	}
+	var body struct {
+		Unlocked []lockEntry `json:"unlocked"`
+	}
+	if err := c.requestJson("DELETE", "/_admin/locks", query, nil, nil, &body, 200); err != nil {
+		return nil, err
+	}
+	return body.Unlocked, nil
+}
+
+func (c *remoteClient) export(path string) (map[string]any, error) {
+	var exportMap map[string]any
+	if err := c.requestJson("GET", "/_admin/export", url.Values{"path": {path}}, nil, nil, &exportMap, 200); err != nil {
+		return nil, err
+	}
+	return exportMap, nil
+}
+
+func (c *remoteClient) importResource(path string, exportMap map[string]any, isOverwrite bool) (bool, error) {
+	body, err := json.Marshal(exportMap)
+	if err != nil {
+		return false, err
+	}
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	header.Set("Overwrite", getOverwriteHeader(isOverwrite))
+	response, err := c.request("POST", "/_admin/import", url.Values{"path": {path}}, header, body, 201, 204)
+	if err != nil {
+		return false, err
+	}
+	response.Body.Close()
+	return response.StatusCode == 204, nil
+}
+
+func (c *remoteClient) close() error {
+	c.httpClient.CloseIdleConnections()
+	return nil
+}
+
+/*
+요청을 보내고 응답 본문을 JSON으로 읽기
+  - @param {any} result 응답 본문을 읽을 값, nil이면 응답 본문을 읽지 않음
+  - @param {...int} statusCodes 성공으로 처리할 응답 코드
+*/
+func (c *remoteClient) requestJson(method string, path string, query url.Values, header http.Header, body []byte, result any, statusCodes ...int) error {
+	response, err := c.request(method, path, query, header, body, statusCodes...)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if result == nil {
+		return nil
+	}
+	return json.NewDecoder(response.Body).Decode(result)
+}
+
+/*
+요청을 보내고 응답 코드 확인
+응답 코드가 statusCodes에 없으면 statusError 반환
+*/
+func (c *remoteClient) request(method string, path string, query url.Values, header http.Header, body []byte, statusCodes ...int) (*http.Response, error) {
+	requestUrl := *c.baseUrl
+	requestUrl.Path = c.baseUrl.Path + path
+	requestUrl.RawQuery = query.Encode()
+
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequest(method, requestUrl.String(), bodyReader)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	if c.username != "" {
+		req.Header.Set("User-Name", c.username)
+	}
+	if c.groupname != "" {
+		req.Header.Set("Group-Name", c.groupname)
+	}
+	if len(c.lockTokens) > 0 {
+		ifHeader := []string{}
+		for _, lockToken := range c.lockTokens {
+			ifHeader = append(ifHeader, "(<opaquelocktoken:"+lockToken+">)")
+		}
+		req.Header.Set("If", strings.Join(ifHeader, " "))
+	}
+
+	response, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	for _, statusCode := range statusCodes {
+		if response.StatusCode == statusCode {
+			return response, nil
+		}
+	}
+	io.Copy(io.Discard, response.Body)
+	response.Body.Close()
+	return nil, statusError{method: method, path: path, statusCode: response.StatusCode}
+}
+
+func getOverwriteHeader(isOverwrite bool) string {
+	if isOverwrite {
+		return "T"
+	}
+	return "F"
+}
+
+func getOwnerQuery(owner string) url.Values {
+	if owner == "" {
+		return url.Values{}
+	}
+	return url.Values{"user": {owner}}
+}
@@ -0,0 +1,339 @@
+package cli
+
+import (
+	"app/audit"
+	"app/class"
+	"app/config"
+	server "app/server"
+	"app/storage"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+/*
+serve: 설정을 불러와 서버 시작
+명령줄 옵션, 환경 변수, 설정 파일, 기본값 순으로 우선하며 데이터 파일이 없으면 빈 트리로 시작
+SIGHUP을 받으면 설정을 다시 불러와 실행 중 바꿀 수 있는 설정을 적용
+SIGINT, SIGTERM을 받으면 처리 중인 요청을 기다린 후 트리 상태를 저장하고 종료
+*/
+func runServe(env *environment, args []string) int {
+	flagSet := newFlagSet(env, "serve")
+	configPath := flagSet.String("config", os.Getenv("RESOURCE_SERVER_CONFIG"), "설정 파일 경로 (.json 또는 .toml)")
+	defaults := config.Default()
+	flagSet.String("data", defaults.Data.Path, "불러올 데이터 파일 경로")
+	flagSet.String("listen", defaults.Server.Listen, "서버 주소 ({host}:{port})")
+	flagSet.String("tls-cert", "", "TLS 인증서 파일 경로, --tls-key와 함께 지정하면 HTTPS로 시작")
+	flagSet.String("tls-key", "", "TLS 개인 키 파일 경로")
+	flagSet.String("audit-dir", defaults.Data.AuditDir, "감사 기록 폴더, 비어있으면 기록하지 않음")
+	flagSet.String("log-level", defaults.Log.Level, "로그 수준 (debug, info, warn, error)")
+	flagSet.String("log-format", defaults.Log.Format, "로그 형식 (text, json)")
+	positionalArgs, err := parseArgs(flagSet, args)
+	if err != nil {
+		return getParseErrorCode(err)
+	}
+	if len(positionalArgs) > 0 {
+		return usageError(env, flagSet, commandUsage("serve"))
+	}
+
+	// 지정한 명령줄 옵션만 설정을 덮어씀
+	setFlags := map[string]bool{}
+	flagSet.Visit(func(f *flag.Flag) {
+		setFlags[f.Name] = true
+	})
+	overrideFlags := func(c *config.Config) {
+		for name, field := range map[string]*string{
+			"data":       &c.Data.Path,
+			"listen":     &c.Server.Listen,
+			"tls-cert":   &c.Server.TLS.CertFile,
+			"tls-key":    &c.Server.TLS.KeyFile,
+			"audit-dir":  &c.Data.AuditDir,
+			"log-level":  &c.Log.Level,
+			"log-format": &c.Log.Format,
+		} {
+			if setFlags[name] {
+				*field = flagSet.Lookup(name).Value.String()
+			}
+		}
+	}
+	serverConfig, err := config.Load(*configPath, overrideFlags)
+	if err != nil {
+		return runError(env, err)
+	}
+	runner, err := newServeRunner(env, serverConfig)
+	if err != nil {
+		return runError(env, err)
+	}
+	defer runner.close()
+
+	// SIGHUP을 받으면 설정을 다시 불러옴
+	reloadSignal := make(chan os.Signal, 1)
+	signal.Notify(reloadSignal, syscall.SIGHUP)
+	defer signal.Stop(reloadSignal)
+	go func() {
+		for range reloadSignal {
+			nextConfig, err := config.Load(*configPath, overrideFlags)
+			if err != nil {
+				runner.logger.Error("설정을 다시 불러오지 못했습니다", slog.Any("error", err))
+				continue
+			}
+			runner.reload(nextConfig)
+		}
+	}()
+
+	// SIGINT, SIGTERM을 받으면 처리 중인 요청을 기다린 후 트리 상태를 저장하고 종료
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	runner.server.RegisterOnShutdown(func(ctx context.Context) error {
+		return runner.save()
+	})
+	if err := runner.server.Start(ctx, serverConfig.GetListenParam()); err != nil {
+		return runError(env, err)
+	}
+	go func() {
+		// 종료 중 신호를 다시 받으면 기다리지 않고 즉시 종료
+		<-ctx.Done()
+		stop()
+	}()
+	if err := runner.server.Wait(); err != nil {
+		return runError(env, err)
+	}
+	return 0
+}
+
+/*
+실행 중인 서버와 설정
+*/
+type serveRunner struct {
+	mutex           sync.Mutex
+	config          config.Config
+	resourceManager *class.ResourceManager
+	server          *server.ResourceManagerServer
+	logger          *slog.Logger
+	logLevel        *slog.LevelVar
+	auditLogger     *audit.Logger
+	// 마지막으로 저장한 트리 상태, 바뀌지 않았으면 저장하지 않음
+	savedJson        string
+	autosaveInterval chan time.Duration
+	stopAutosave     chan struct{}
+}
+
+/*
+설정으로 트리를 불러오고 서버 생성
+*/
+func newServeRunner(env *environment, serverConfig config.Config) (*serveRunner, error) {
+	logLevel := &slog.LevelVar{}
+	logger, err := server.NewLogger(env.stderr, server.LogParam{
+		Level:    serverConfig.Log.Level,
+		Format:   serverConfig.Log.Format,
+		LevelVar: logLevel,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resourceManager, savedJson, err := loadResourceManager(serverConfig.Data.Path)
+	if err != nil {
+		return nil, fmt.Errorf("데이터 파일을 불러오지 못했습니다: %w", err)
+	}
+	if serverConfig.Storage.Backend == storage.BACKEND_FILE {
+		contentStore, err := storage.NewFileBlobStore(serverConfig.Storage.Dir)
+		if err != nil {
+			return nil, fmt.Errorf("내용 저장소를 열지 못했습니다: %w", err)
+		}
+		resourceManager.SetContentStore(contentStore)
+	}
+	// 저장소에 없는 내용을 가리키는 트리로 시작하면 파일을 읽을 수 없음
+	if err := resourceManager.CheckContentStore(); err != nil {
+		return nil, fmt.Errorf("데이터 파일과 내용 저장소가 맞지 않습니다: %w", err)
+	}
+
+	r := &serveRunner{
+		config:           serverConfig,
+		resourceManager:  resourceManager,
+		server:           server.NewServer(resourceManager),
+		logger:           logger,
+		logLevel:         logLevel,
+		savedJson:        savedJson,
+		autosaveInterval: make(chan time.Duration, 1),
+		stopAutosave:     make(chan struct{}),
+	}
+	if serverConfig.Data.AuditDir != "" {
+		r.auditLogger, err = audit.NewLogger(audit.LoggerParam{
+			Dir:      serverConfig.Data.AuditDir,
+			MaxBytes: 10 * 1024 * 1024,
+			MaxAge:   24 * time.Hour,
+		})
+		if err != nil {
+			return nil, err
+		}
+		resourceManager.SetAuditLogger(r.auditLogger)
+	}
+	r.server.SetLogger(logger)
+	if err := r.applySettings(config.Config{}, serverConfig); err != nil {
+		r.close()
+		return nil, err
+	}
+
+	go r.runAutosave(time.Duration(serverConfig.Data.AutosaveInterval))
+	return r, nil
+}
+
+/*
+실행 중 바꿀 수 있는 설정 적용
+이전 설정에만 있던 저장 용량 제한은 해제
+*/
+func (r *serveRunner) applySettings(previous config.Config, next config.Config) error {
+	if err := r.server.SetAuthParam(next.GetAuthParam()); err != nil {
+		return err
+	}
+	r.server.SetLockTimeoutPolicy(next.GetLockTimeoutPolicy())
+	level, _ := server.ParseLogLevel(next.Log.Level)
+	r.logLevel.Set(level)
+
+	for username := range previous.Quotas.Users {
+		r.resourceManager.SetUserQuota(username, class.ResourceQuota{})
+	}
+	for username, limit := range next.Quotas.Users {
+		r.resourceManager.SetUserQuota(username, limit.ToResourceQuota())
+	}
+	for groupname := range previous.Quotas.Groups {
+		r.resourceManager.SetGroupQuota(groupname, class.ResourceQuota{})
+	}
+	for groupname, limit := range next.Quotas.Groups {
+		r.resourceManager.SetGroupQuota(groupname, limit.ToResourceQuota())
+	}
+	for path := range previous.Quotas.Directories {
+		r.resourceManager.SetDirectoryQuota(path, class.ResourceQuota{})
+	}
+	for path, limit := range next.Quotas.Directories {
+		// 폴더가 없어도 다른 설정은 적용
+		if err := r.resourceManager.SetDirectoryQuota(path, limit.ToResourceQuota()); err != nil {
+			r.logger.Warn("폴더의 저장 용량 제한을 설정하지 못했습니다", slog.String("path", path), slog.Any("error", err))
+		}
+	}
+	return nil
+}
+
+/*
+다시 불러온 설정 적용
+서버를 다시 시작해야 하는 설정이 바뀌었으면 경고를 기록하고 무시
+*/
+func (r *serveRunner) reload(next config.Config) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if changes := r.config.GetRestartRequiredChanges(next); len(changes) > 0 {
+		r.logger.Warn("다시 시작해야 적용되는 설정은 무시합니다", slog.Any("settings", changes))
+	}
+	if err := r.applySettings(r.config, next); err != nil {
+		r.logger.Error("설정을 적용하지 못했습니다", slog.Any("error", err))
+		return
+	}
+	if next.Data.AutosaveInterval != r.config.Data.AutosaveInterval {
+		// 아직 반영되지 않은 이전 간격은 버림
+		select {
+		case <-r.autosaveInterval:
+		default:
+		}
+		r.autosaveInterval <- time.Duration(next.Data.AutosaveInterval)
+	}
+
+	// 다시 시작해야 하는 설정은 이전 값을 유지
+	r.config.Auth = next.Auth
+	r.config.Locks = next.Locks
+	r.config.Quotas = next.Quotas
+	r.config.Log.Level = next.Log.Level
+	r.config.Data.AutosaveInterval = next.Data.AutosaveInterval
+	r.logger.Info("설정을 다시 불러왔습니다")
+}
+
+/*
+설정한 간격마다 트리 상태를 저장
+간격이 0이면 autosaveInterval로 새 간격을 받을 때까지 저장하지 않음
+*/
+func (r *serveRunner) runAutosave(interval time.Duration) {
+	ticker := time.NewTicker(time.Hour)
+	ticker.Stop()
+	if interval > 0 {
+		ticker.Reset(interval)
+	}
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.stopAutosave:
+			return
+		case interval = <-r.autosaveInterval:
+			ticker.Stop()
+			if interval > 0 {
+				ticker.Reset(interval)
+			}
+		case <-ticker.C:
+			if err := r.save(); err != nil {
+				r.logger.Error("트리 상태를 저장하지 못했습니다", slog.Any("error", err))
+			}
+		}
+	}
+}
+
+/*
+트리 상태가 바뀌었으면 데이터 파일에 저장
+*/
+func (r *serveRunner) save() error {
+	startedAt := time.Now()
+	jsonData, err := r.resourceManager.ToJson()
+	if err != nil {
+		return err
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if jsonData == r.savedJson {
+		return nil
+	}
+	if err := writeFileAtomic(r.config.Data.Path, []byte(jsonData)); err != nil {
+		return err
+	}
+	r.savedJson = jsonData
+	r.server.ObservePersistenceFlush(time.Since(startedAt))
+	return nil
+}
+
+/*
+자동 저장을 멈추고 감사 기록기를 닫음
+*/
+func (r *serveRunner) close() {
+	close(r.stopAutosave)
+	if r.auditLogger != nil {
+		r.auditLogger.Close()
+	}
+}
+
+/*
+데이터 파일로 ResourceManager 생성
+파일이 없으면 빈 ResourceManager 반환
+  - @return {string} 불러온 트리 상태의 JSON, 파일이 없으면 빈 문자열
+*/
+func loadResourceManager(dataPath string) (*class.ResourceManager, string, error) {
+	jsonData, err := os.ReadFile(dataPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return class.NewResourceManager(), "", nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	resourceManager, err := class.ParseJsonResourceManager(string(jsonData))
+	if err != nil {
+		return nil, "", err
+	}
+	return resourceManager, string(jsonData), nil
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	audit "app/audit"
+	"fmt"
+	"os"
+)
+
+/*
+감사 기록 폴더의 해시 연결 검증
+사용법: audit-verify {감사 기록 폴더}
+*/
+func main() {
+	if len(os.Args) != 2 {
+		fmt.Println("사용법: audit-verify {감사 기록 폴더}")
+		os.Exit(2)
+	}
+
+	count, err := audit.Verify(os.Args[1])
+	if err != nil {
+		fmt.Printf("검증 실패 (정상 기록 %d개): %v\n", count, err)
+		os.Exit(1)
+	}
+	fmt.Printf("검증 완료: 기록 %d개\n", count)
+}
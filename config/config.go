@@ -0,0 +1,319 @@
+package config
+
+import (
+	"app/class"
+	server "app/server"
+	"app/storage"
+	"app/util"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"time"
+)
+
+/*
+서버 설정
+기본값, 설정 파일, 환경 변수 순으로 덮어씀
+*/
+type Config struct {
+	Server  ServerConfig  `json:"server"`
+	Data    DataConfig    `json:"data"`
+	Storage StorageConfig `json:"storage"`
+	Auth    AuthConfig    `json:"auth"`
+	Locks   LockConfig    `json:"locks"`
+	Quotas  QuotaConfig   `json:"quotas"`
+	Log     LogConfig     `json:"log"`
+}
+
+type ServerConfig struct {
+	// 서버 주소 ({host}:{port})
+	Listen string    `json:"listen"`
+	TLS    TLSConfig `json:"tls"`
+	// SIGINT, SIGTERM을 받았을 때 처리 중인 요청을 기다리는 최대 시간
+	ShutdownTimeout Duration `json:"shutdownTimeout"`
+}
+
+/*
+TLS 설정
+두 파일을 모두 지정하면 HTTPS로 시작
+*/
+type TLSConfig struct {
+	CertFile string `json:"certFile"`
+	KeyFile  string `json:"keyFile"`
+}
+
+type DataConfig struct {
+	// 트리 상태를 저장하는 데이터 파일 경로
+	Path string `json:"path"`
+	// 감사 기록 폴더, 비어있으면 기록하지 않음
+	AuditDir string `json:"auditDir"`
+	// 트리 상태를 데이터 파일에 저장하는 간격, 0이면 자동으로 저장하지 않음
+	AutosaveInterval Duration `json:"autosaveInterval"`
+}
+
+type StorageConfig struct {
+	// storage.BACKEND_MEMORY 또는 storage.BACKEND_FILE
+	Backend string `json:"backend"`
+	// storage.BACKEND_FILE의 내용 저장 폴더
+	Dir string `json:"dir"`
+}
+
+type AuthConfig struct {
+	// server.AUTH_PROVIDER_HEADER
+	Providers []string `json:"providers"`
+}
+
+type LockConfig struct {
+	// `Timeout` 헤더가 없거나 `Infinite`일 때의 잠금 만료 시간, 0이면 만료되지 않음
+	DefaultTimeout Duration `json:"defaultTimeout"`
+	// 요청할 수 있는 최대 잠금 만료 시간, 0이면 제한 없음
+	MaxTimeout Duration `json:"maxTimeout"`
+}
+
+/*
+저장 용량 제한
+설정에 있는 유저, 그룹, 폴더의 제한만 덮어쓰며 관리자 API로 설정한 다른 제한은 유지
+*/
+type QuotaConfig struct {
+	Users  map[string]QuotaLimit `json:"users"`
+	Groups map[string]QuotaLimit `json:"groups"`
+	// 폴더 경로별 제한
+	Directories map[string]QuotaLimit `json:"directories"`
+}
+
+type QuotaLimit struct {
+	// 최대 크기(byte), 0이면 제한 없음
+	MaxBytes int64 `json:"maxBytes"`
+	// 최대 리소스 개수, 0이면 제한 없음
+	MaxNodes int64 `json:"maxNodes"`
+}
+
+type LogConfig struct {
+	// "debug", "info", "warn", "error"
+	Level string `json:"level"`
+	// server.LOG_FORMAT_TEXT 또는 server.LOG_FORMAT_JSON
+	Format string `json:"format"`
+}
+
+/*
+"30s", "5m" 처럼 문자열로 표현하는 시간
+*/
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("시간은 \"30s\", \"5m\" 형식의 문자열이어야 합니다")
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("올바르지 않은 시간입니다: %s", value)
+	}
+	*d = Duration(duration)
+	return nil
+}
+
+/*
+기본 설정 반환
+*/
+func Default() Config {
+	return Config{
+		Server: ServerConfig{
+			Listen:          ":3000",
+			ShutdownTimeout: Duration(server.DEFAULT_SHUTDOWN_TIMEOUT),
+		},
+		Data: DataConfig{
+			Path:             "./data.json",
+			AuditDir:         "./audit",
+			AutosaveInterval: Duration(time.Minute),
+		},
+		Storage: StorageConfig{
+			Backend: storage.BACKEND_FILE,
+			Dir:     "./blobs",
+		},
+		Auth: AuthConfig{
+			Providers: []string{server.AUTH_PROVIDER_HEADER},
+		},
+		Quotas: QuotaConfig{
+			Users:       map[string]QuotaLimit{},
+			Groups:      map[string]QuotaLimit{},
+			Directories: map[string]QuotaLimit{},
+		},
+		Log: LogConfig{
+			Level:  "info",
+			Format: server.LOG_FORMAT_TEXT,
+		},
+	}
+}
+
+/*
+설정이 올바른지 확인
+올바르지 않은 모든 항목의 error를 합쳐 반환
+*/
+func (c Config) Validate() error {
+	errs := []error{}
+	invalid := func(key string, format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%s: %s", key, fmt.Sprintf(format, args...)))
+	}
+
+	if _, _, err := net.SplitHostPort(c.Server.Listen); err != nil {
+		invalid("server.listen", "서버 주소는 {host}:{port} 형식이어야 합니다: %q", c.Server.Listen)
+	}
+	if (c.Server.TLS.CertFile == "") != (c.Server.TLS.KeyFile == "") {
+		invalid("server.tls", "certFile과 keyFile은 함께 지정해야 합니다")
+	}
+	for _, file := range []struct{ key, path string }{
+		{"server.tls.certFile", c.Server.TLS.CertFile},
+		{"server.tls.keyFile", c.Server.TLS.KeyFile},
+	} {
+		if _, err := os.Stat(file.path); file.path != "" && err != nil {
+			invalid(file.key, "파일을 읽을 수 없습니다: %v", err)
+		}
+	}
+
+	if c.Server.ShutdownTimeout <= 0 {
+		invalid("server.shutdownTimeout", "0보다 커야 합니다")
+	}
+
+	if c.Data.Path == "" {
+		invalid("data.path", "데이터 파일 경로가 필요합니다")
+	}
+	if c.Data.AutosaveInterval < 0 {
+		invalid("data.autosaveInterval", "0 이상이어야 합니다")
+	}
+
+	switch c.Storage.Backend {
+	case storage.BACKEND_MEMORY:
+	case storage.BACKEND_FILE:
+		if c.Storage.Dir == "" {
+			invalid("storage.dir", "%s 저장소에는 폴더가 필요합니다", storage.BACKEND_FILE)
+		}
+	default:
+		invalid("storage.backend", "%s 또는 %s이어야 합니다: %q", storage.BACKEND_MEMORY, storage.BACKEND_FILE, c.Storage.Backend)
+	}
+
+	if err := c.GetAuthParam().Validate(); err != nil {
+		invalid("auth", "%v", err)
+	}
+	if len(c.Auth.Providers) == 0 {
+		invalid("auth.providers", "인증 방법이 하나 이상 필요합니다")
+	}
+
+	if c.Locks.DefaultTimeout < 0 || c.Locks.MaxTimeout < 0 {
+		invalid("locks", "잠금 만료 시간은 0 이상이어야 합니다")
+	}
+	if c.Locks.MaxTimeout > 0 && c.Locks.DefaultTimeout > c.Locks.MaxTimeout {
+		invalid("locks.defaultTimeout", "maxTimeout보다 클 수 없습니다")
+	}
+
+	for _, quotas := range []struct {
+		scope  string
+		limits map[string]QuotaLimit
+	}{
+		{"users", c.Quotas.Users},
+		{"groups", c.Quotas.Groups},
+		{"directories", c.Quotas.Directories},
+	} {
+		scope := quotas.scope
+		names := []string{}
+		for name := range quotas.limits {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			limit := quotas.limits[name]
+			if limit.MaxBytes < 0 || limit.MaxNodes < 0 {
+				invalid("quotas."+scope+"."+name, "제한은 0 이상이어야 합니다")
+			}
+			if scope == "directories" && !util.IsValidPath(name) {
+				invalid("quotas.directories."+name, "올바르지 않은 경로입니다")
+			}
+		}
+	}
+
+	if _, err := server.ParseLogLevel(c.Log.Level); err != nil {
+		invalid("log.level", "%v", err)
+	}
+	if err := server.ValidateLogFormat(c.Log.Format); err != nil {
+		invalid("log.format", "%v", err)
+	}
+	return errors.Join(errs...)
+}
+
+/*
+next로 바꿀 때 서버를 다시 시작해야 적용되는 항목 반환
+인증, 잠금 만료 시간, 저장 용량 제한, 로그 수준, 자동 저장 간격은 실행 중 적용할 수 있음
+*/
+func (c Config) GetRestartRequiredChanges(next Config) []string {
+	changes := []string{}
+	if c.Server.Listen != next.Server.Listen {
+		changes = append(changes, "server.listen")
+	}
+	if c.Server.TLS != next.Server.TLS {
+		changes = append(changes, "server.tls")
+	}
+	if c.Server.ShutdownTimeout != next.Server.ShutdownTimeout {
+		changes = append(changes, "server.shutdownTimeout")
+	}
+	if c.Data.Path != next.Data.Path {
+		changes = append(changes, "data.path")
+	}
+	if c.Data.AuditDir != next.Data.AuditDir {
+		changes = append(changes, "data.auditDir")
+	}
+	if c.Storage != next.Storage {
+		changes = append(changes, "storage")
+	}
+	if c.Log.Format != next.Log.Format {
+		changes = append(changes, "log.format")
+	}
+	return changes
+}
+
+/*
+서버 시작 설정 반환
+*/
+func (c Config) GetListenParam() server.ListenParam {
+	return server.ListenParam{
+		Address:         c.Server.Listen,
+		CertFile:        c.Server.TLS.CertFile,
+		KeyFile:         c.Server.TLS.KeyFile,
+		ShutdownTimeout: time.Duration(c.Server.ShutdownTimeout),
+	}
+}
+
+/*
+서버에 적용할 인증 설정 반환
+*/
+func (c Config) GetAuthParam() server.AuthParam {
+	return server.AuthParam{
+		Providers: util.CloneSlice(c.Auth.Providers),
+	}
+}
+
+/*
+서버에 적용할 잠금 만료 시간 정책 반환
+*/
+func (c Config) GetLockTimeoutPolicy() server.LockTimeoutPolicy {
+	return server.LockTimeoutPolicy{
+		DefaultTimeout: time.Duration(c.Locks.DefaultTimeout),
+		MaxTimeout:     time.Duration(c.Locks.MaxTimeout),
+	}
+}
+
+/*
+저장 용량 제한을 ResourceQuota로 변환
+*/
+func (l QuotaLimit) ToResourceQuota() class.ResourceQuota {
+	return class.ResourceQuota{
+		MaxBytes: l.MaxBytes,
+		MaxNodes: l.MaxNodes,
+	}
+}
@@ -0,0 +1,141 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+/*
+설정 파일과 환경 변수로 설정을 불러와 확인
+  - @param {string} path 설정 파일 경로, `.toml`이면 TOML, 그 외에는 JSON, 빈 문자열이면 기본값과 환경 변수만 사용
+  - @param {...func(*Config)} overrides 환경 변수 다음에 적용할 변경 (예시: 명령줄 옵션)
+*/
+func Load(path string, overrides ...func(config *Config)) (Config, error) {
+	return LoadWithEnv(path, os.LookupEnv, overrides...)
+}
+
+/*
+설정 파일과 lookupEnv가 반환하는 환경 변수로 설정을 불러와 확인
+*/
+func LoadWithEnv(path string, lookupEnv func(name string) (string, bool), overrides ...func(config *Config)) (Config, error) {
+	config := Default()
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return Config{}, fmt.Errorf("설정 파일을 읽을 수 없습니다: %w", err)
+		}
+		if err := decode(path, data, &config); err != nil {
+			return Config{}, fmt.Errorf("%s: 설정 파일 형식이 올바르지 않습니다: %w", path, err)
+		}
+	}
+	if err := applyEnv(&config, lookupEnv); err != nil {
+		return Config{}, err
+	}
+	for _, override := range overrides {
+		override(&config)
+	}
+	if err := config.Validate(); err != nil {
+		return Config{}, fmt.Errorf("설정이 올바르지 않습니다\n%w", err)
+	}
+	return config, nil
+}
+
+/*
+설정 파일의 내용을 config에 덮어씀
+알 수 없는 키가 있으면 error 반환
+*/
+func decode(path string, data []byte, config *Config) error {
+	if strings.ToLower(filepath.Ext(path)) == ".toml" {
+		tomlMap, err := parseToml(string(data))
+		if err != nil {
+			return err
+		}
+		if data, err = json.Marshal(tomlMap); err != nil {
+			return err
+		}
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(string(data)))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(config)
+}
+
+/*
+설정을 덮어쓰는 환경 변수
+*/
+type envOverride struct {
+	name string
+	set  func(config *Config, value string) error
+}
+
+func stringEnv(name string, field func(config *Config) *string) envOverride {
+	return envOverride{name, func(config *Config, value string) error {
+		*field(config) = value
+		return nil
+	}}
+}
+
+func durationEnv(name string, field func(config *Config) *Duration) envOverride {
+	return envOverride{name, func(config *Config, value string) error {
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("올바르지 않은 시간입니다: %s", value)
+		}
+		*field(config) = Duration(duration)
+		return nil
+	}}
+}
+
+/*
+환경 변수 목록, 뒤의 환경 변수가 앞의 환경 변수를 덮어씀
+유저, 그룹별 설정(인증 유저, 저장 용량 제한)은 설정 파일로만 지정
+*/
+var envOverrides = []envOverride{
+	stringEnv("RESOURCE_SERVER_LISTEN", func(c *Config) *string { return &c.Server.Listen }),
+	stringEnv("RESOURCE_SERVER_TLS_CERT_FILE", func(c *Config) *string { return &c.Server.TLS.CertFile }),
+	stringEnv("RESOURCE_SERVER_TLS_KEY_FILE", func(c *Config) *string { return &c.Server.TLS.KeyFile }),
+	durationEnv("RESOURCE_SERVER_SHUTDOWN_TIMEOUT", func(c *Config) *Duration { return &c.Server.ShutdownTimeout }),
+	stringEnv("RESOURCE_SERVER_DATA_PATH", func(c *Config) *string { return &c.Data.Path }),
+	stringEnv("RESOURCE_SERVER_AUDIT_DIR", func(c *Config) *string { return &c.Data.AuditDir }),
+	durationEnv("RESOURCE_SERVER_AUTOSAVE_INTERVAL", func(c *Config) *Duration { return &c.Data.AutosaveInterval }),
+	stringEnv("RESOURCE_SERVER_STORAGE_BACKEND", func(c *Config) *string { return &c.Storage.Backend }),
+	stringEnv("RESOURCE_SERVER_STORAGE_DIR", func(c *Config) *string { return &c.Storage.Dir }),
+	{"RESOURCE_SERVER_AUTH_PROVIDERS", func(config *Config, value string) error {
+		// 쉼표로 구분 (예시: "header")
+		config.Auth.Providers = []string{}
+		for _, provider := range strings.Split(value, ",") {
+			if provider = strings.TrimSpace(provider); provider != "" {
+				config.Auth.Providers = append(config.Auth.Providers, provider)
+			}
+		}
+		return nil
+	}},
+	durationEnv("RESOURCE_SERVER_LOCK_DEFAULT_TIMEOUT", func(c *Config) *Duration { return &c.Locks.DefaultTimeout }),
+	durationEnv("RESOURCE_SERVER_LOCK_MAX_TIMEOUT", func(c *Config) *Duration { return &c.Locks.MaxTimeout }),
+	// 이전 버전과의 호환을 위해 LOG_LEVEL, LOG_FORMAT도 지원
+	stringEnv("LOG_LEVEL", func(c *Config) *string { return &c.Log.Level }),
+	stringEnv("LOG_FORMAT", func(c *Config) *string { return &c.Log.Format }),
+	stringEnv("RESOURCE_SERVER_LOG_LEVEL", func(c *Config) *string { return &c.Log.Level }),
+	stringEnv("RESOURCE_SERVER_LOG_FORMAT", func(c *Config) *string { return &c.Log.Format }),
+}
+
+/*
+설정된 환경 변수로 config를 덮어씀
+값이 빈 환경 변수는 무시
+*/
+func applyEnv(config *Config, lookupEnv func(name string) (string, bool)) error {
+	for _, override := range envOverrides {
+		value, ok := lookupEnv(override.name)
+		if !ok || value == "" {
+			continue
+		}
+		if err := override.set(config, value); err != nil {
+			return fmt.Errorf("%s: %w", override.name, err)
+		}
+	}
+	return nil
+}
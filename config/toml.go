@@ -0,0 +1,381 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+/*
+TOML의 일부를 map으로 변환
+지원: 주석, 테이블(`[a.b]`), 점으로 연결한 키, 따옴표로 감싼 키, 문자열, 정수, 불리언, 배열, 인라인 테이블
+미지원: 여러 줄 문자열, 실수, 날짜, 테이블 배열(`[[a]]`)
+*/
+func parseToml(data string) (map[string]any, error) {
+	parser := &tomlParser{data: data, line: 1}
+	root := map[string]any{}
+	if err := parser.parseDocument(root); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+type tomlParser struct {
+	data string
+	pos  int
+	line int
+}
+
+func (p *tomlParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("%d번째 줄: %s", p.line, fmt.Sprintf(format, args...))
+}
+
+func (p *tomlParser) isEnd() bool {
+	return p.pos >= len(p.data)
+}
+
+func (p *tomlParser) peek() byte {
+	if p.isEnd() {
+		return 0
+	}
+	return p.data[p.pos]
+}
+
+/*
+공백과 주석 건너뛰기
+isMultiline이 true이면 줄바꿈도 건너뜀
+*/
+func (p *tomlParser) skipSpace(isMultiline bool) {
+	for !p.isEnd() {
+		switch c := p.peek(); {
+		case c == ' ' || c == '\t' || c == '\r':
+			p.pos++
+		case c == '#':
+			for !p.isEnd() && p.peek() != '\n' {
+				p.pos++
+			}
+		case c == '\n' && isMultiline:
+			p.pos++
+			p.line++
+		default:
+			return
+		}
+	}
+}
+
+/*
+줄의 끝인지 확인하고 다음 줄로 이동
+*/
+func (p *tomlParser) expectLineEnd() error {
+	p.skipSpace(false)
+	if p.isEnd() {
+		return nil
+	}
+	if p.peek() != '\n' {
+		return p.errorf("줄 끝에 올바르지 않은 문자가 있습니다: %q", p.peek())
+	}
+	p.pos++
+	p.line++
+	return nil
+}
+
+func (p *tomlParser) parseDocument(root map[string]any) error {
+	current := root
+	for {
+		p.skipSpace(true)
+		if p.isEnd() {
+			return nil
+		}
+
+		if p.peek() == '[' {
+			p.pos++
+			if p.peek() == '[' {
+				return p.errorf("테이블 배열은 지원하지 않습니다")
+			}
+			p.skipSpace(false)
+			keys, err := p.parseKey()
+			if err != nil {
+				return err
+			}
+			p.skipSpace(false)
+			if p.peek() != ']' {
+				return p.errorf("테이블 이름이 ']'로 끝나야 합니다")
+			}
+			p.pos++
+			if current, err = p.getTable(root, keys); err != nil {
+				return err
+			}
+		} else if err := p.parseKeyValue(current); err != nil {
+			return err
+		}
+
+		if err := p.expectLineEnd(); err != nil {
+			return err
+		}
+	}
+}
+
+/*
+`키 = 값`을 파싱하여 table에 추가
+*/
+func (p *tomlParser) parseKeyValue(table map[string]any) error {
+	keys, err := p.parseKey()
+	if err != nil {
+		return err
+	}
+	p.skipSpace(false)
+	if p.peek() != '=' {
+		return p.errorf("키 뒤에 '='가 필요합니다")
+	}
+	p.pos++
+	p.skipSpace(false)
+	value, err := p.parseValue()
+	if err != nil {
+		return err
+	}
+
+	parent, err := p.getTable(table, keys[:len(keys)-1])
+	if err != nil {
+		return err
+	}
+	key := keys[len(keys)-1]
+	if _, ok := parent[key]; ok {
+		return p.errorf("키가 중복됩니다: %s", strings.Join(keys, "."))
+	}
+	parent[key] = value
+	return nil
+}
+
+/*
+점으로 연결한 키를 파싱 (예시: `a."b.c".d`)
+*/
+func (p *tomlParser) parseKey() ([]string, error) {
+	keys := []string{}
+	for {
+		var key string
+		var err error
+		switch c := p.peek(); {
+		case c == '"':
+			key, err = p.parseBasicString()
+		case c == '\'':
+			key, err = p.parseLiteralString()
+		default:
+			start := p.pos
+			for !p.isEnd() && isBareKeyChar(p.peek()) {
+				p.pos++
+			}
+			if start == p.pos {
+				return nil, p.errorf("키가 필요합니다")
+			}
+			key = p.data[start:p.pos]
+		}
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+
+		p.skipSpace(false)
+		if p.peek() != '.' {
+			return keys, nil
+		}
+		p.pos++
+		p.skipSpace(false)
+	}
+}
+
+func isBareKeyChar(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c == '_' || c == '-'
+}
+
+/*
+키 경로의 테이블 반환, 없으면 생성
+*/
+func (p *tomlParser) getTable(root map[string]any, keys []string) (map[string]any, error) {
+	table := root
+	for i, key := range keys {
+		value, ok := table[key]
+		if !ok {
+			child := map[string]any{}
+			table[key] = child
+			table = child
+			continue
+		}
+		child, ok := value.(map[string]any)
+		if !ok {
+			return nil, p.errorf("테이블이 아닌 키입니다: %s", strings.Join(keys[:i+1], "."))
+		}
+		table = child
+	}
+	return table, nil
+}
+
+func (p *tomlParser) parseValue() (any, error) {
+	switch c := p.peek(); {
+	case c == '"':
+		if strings.HasPrefix(p.data[p.pos:], `"""`) {
+			return nil, p.errorf("여러 줄 문자열은 지원하지 않습니다")
+		}
+		return p.parseBasicString()
+	case c == '\'':
+		if strings.HasPrefix(p.data[p.pos:], `'''`) {
+			return nil, p.errorf("여러 줄 문자열은 지원하지 않습니다")
+		}
+		return p.parseLiteralString()
+	case c == '[':
+		return p.parseArray()
+	case c == '{':
+		return p.parseInlineTable()
+	case strings.HasPrefix(p.data[p.pos:], "true"):
+		p.pos += len("true")
+		return true, nil
+	case strings.HasPrefix(p.data[p.pos:], "false"):
+		p.pos += len("false")
+		return false, nil
+	case c == '+' || c == '-' || (c >= '0' && c <= '9'):
+		return p.parseInteger()
+	default:
+		return nil, p.errorf("값이 필요합니다")
+	}
+}
+
+/*
+큰따옴표 문자열, `\"`, `\\`, `\n`, `\t`, `\r`, `\uXXXX` 이스케이프 지원
+*/
+func (p *tomlParser) parseBasicString() (string, error) {
+	p.pos++
+	var builder strings.Builder
+	for {
+		if p.isEnd() || p.peek() == '\n' {
+			return "", p.errorf("문자열이 큰따옴표로 끝나지 않았습니다")
+		}
+		c := p.peek()
+		p.pos++
+		if c == '"' {
+			return builder.String(), nil
+		}
+		if c != '\\' {
+			builder.WriteByte(c)
+			continue
+		}
+
+		escape := p.peek()
+		p.pos++
+		switch escape {
+		case '"', '\\':
+			builder.WriteByte(escape)
+		case 'n':
+			builder.WriteByte('\n')
+		case 't':
+			builder.WriteByte('\t')
+		case 'r':
+			builder.WriteByte('\r')
+		case 'u':
+			if p.pos+4 > len(p.data) {
+				return "", p.errorf("올바르지 않은 유니코드 이스케이프입니다")
+			}
+			code, err := strconv.ParseUint(p.data[p.pos:p.pos+4], 16, 32)
+			if err != nil {
+				return "", p.errorf("올바르지 않은 유니코드 이스케이프입니다")
+			}
+			builder.WriteRune(rune(code))
+			p.pos += 4
+		default:
+			return "", p.errorf("지원하지 않는 이스케이프입니다: \\%c", escape)
+		}
+	}
+}
+
+/*
+작은따옴표 문자열, 이스케이프 없음
+*/
+func (p *tomlParser) parseLiteralString() (string, error) {
+	p.pos++
+	start := p.pos
+	for !p.isEnd() && p.peek() != '\'' && p.peek() != '\n' {
+		p.pos++
+	}
+	if p.peek() != '\'' {
+		return "", p.errorf("문자열이 작은따옴표로 끝나지 않았습니다")
+	}
+	value := p.data[start:p.pos]
+	p.pos++
+	return value, nil
+}
+
+func (p *tomlParser) parseInteger() (int64, error) {
+	start := p.pos
+	if p.peek() == '+' || p.peek() == '-' {
+		p.pos++
+	}
+	for !p.isEnd() && ((p.peek() >= '0' && p.peek() <= '9') || p.peek() == '_') {
+		p.pos++
+	}
+	if c := p.peek(); c == '.' || c == 'e' || c == 'E' {
+		return 0, p.errorf("실수는 지원하지 않습니다")
+	}
+	value, err := strconv.ParseInt(strings.ReplaceAll(p.data[start:p.pos], "_", ""), 10, 64)
+	if err != nil {
+		return 0, p.errorf("올바르지 않은 정수입니다: %s", p.data[start:p.pos])
+	}
+	return value, nil
+}
+
+/*
+배열, 여러 줄에 걸쳐 쓸 수 있으며 마지막 값 뒤의 ','를 허용
+*/
+func (p *tomlParser) parseArray() ([]any, error) {
+	p.pos++
+	values := []any{}
+	for {
+		p.skipSpace(true)
+		if p.peek() == ']' {
+			p.pos++
+			return values, nil
+		}
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+
+		p.skipSpace(true)
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case ']':
+			p.pos++
+			return values, nil
+		default:
+			return nil, p.errorf("배열의 값 뒤에는 ',' 또는 ']'가 필요합니다")
+		}
+	}
+}
+
+/*
+인라인 테이블 (예시: `{ maxBytes = 1024, maxNodes = 10 }`), 한 줄에 써야 함
+*/
+func (p *tomlParser) parseInlineTable() (map[string]any, error) {
+	p.pos++
+	table := map[string]any{}
+	p.skipSpace(false)
+	if p.peek() == '}' {
+		p.pos++
+		return table, nil
+	}
+	for {
+		p.skipSpace(false)
+		if err := p.parseKeyValue(table); err != nil {
+			return nil, err
+		}
+		p.skipSpace(false)
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case '}':
+			p.pos++
+			return table, nil
+		default:
+			return nil, p.errorf("인라인 테이블의 값 뒤에는 ',' 또는 '}'가 필요합니다")
+		}
+	}
+}
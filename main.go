@@ -1,19 +1,10 @@
 package main
 
 import (
-	class "app/class"
-	server "app/server"
+	cli "app/cli"
 	"os"
 )
 
 func main() {
-	json, err := os.ReadFile("./test.json")
-	if err != nil {
-		return
-	}
-	resourceManager := class.FromJsonResourceManager(string(json))
-	resourceManager.AddUserPermission("/test", "snom", "read")
-	resourceManager.CreateResource("/test/ass.txt", false)
-	resourceManagerServer := server.NewServer(resourceManager)
-	resourceManagerServer.Listen(3000)
+	os.Exit(cli.Run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
 }
@@ -0,0 +1,90 @@
+package app
+
+import (
+	"app/class"
+	"net/http"
+	"time"
+)
+
+/*
+요청한 유저가 관리자인지 여부 반환
+루트 리소스에 "all" 권한이 있으면 관리자
+*/
+func (s *ResourceManagerServer) isAdmin(req *http.Request) bool {
+	username := req.Header.Get("User-Name")
+	groupname := req.Header.Get("Group-Name")
+
+	isAdmin := false
+	s.getResourceManager(req).View(func(m *class.ResourceManager) {
+		rootResourceObject := m.GetResourceObject("/")
+		isAdmin = rootResourceObject.CheckUserPermission(username, "all") ||
+			rootResourceObject.CheckGroupPermission(groupname, "all")
+	})
+	return isAdmin
+}
+
+/*
+/_admin/locks: 트리 전체의 잠금 목록 조회(GET), 강제 해제(DELETE)
+`user` 쿼리로 해당 유저가 소유한 잠금만 대상으로 지정 가능
+`path` 쿼리로 해당 경로에 건 잠금만 강제 해제 가능
+*/
+func (s *ResourceManagerServer) handleAdminLocks(res http.ResponseWriter, req *http.Request) {
+	if !s.checkAdmin(req) {
+		res.WriteHeader(403)
+		return
+	}
+
+	owner := req.URL.Query().Get("user")
+
+	switch req.Method {
+	case ("GET"):
+		writeJson(res, 200, map[string]any{
+			"locks": lockListToMaps(s.getResourceManager(req).GetActiveLocks(owner)),
+		})
+	case ("DELETE"):
+		if path := req.URL.Query().Get("path"); path != "" {
+			locks, err := s.getResourceManager(req).UnlockForceWithResult(path)
+			if err != nil {
+				res.WriteHeader(getErrorStatusCode(err))
+				return
+			}
+			writeJson(res, 200, map[string]any{
+				"unlocked": lockListToMaps(locks),
+			})
+			return
+		}
+		writeJson(res, 200, map[string]any{
+			"unlocked": lockListToMaps(s.getResourceManager(req).UnlockForceAll(owner)),
+		})
+	default:
+		res.Header().Set("Allow", "GET, DELETE")
+		res.WriteHeader(405)
+	}
+}
+
+/*
+잠금 목록을 응답용 map 목록으로 변환
+*/
+func lockListToMaps(locks []class.ResourceLock) []map[string]any {
+	lockMaps := []map[string]any{}
+	for _, lock := range locks {
+		depth := "0"
+		if lock.IsDepthInfinity() {
+			depth = "infinity"
+		}
+		expiresAt := ""
+		if !lock.GetExpiresAt().IsZero() {
+			expiresAt = lock.GetExpiresAt().UTC().Format(time.RFC3339)
+		}
+
+		lockMaps = append(lockMaps, map[string]any{
+			"path":      lock.GetRoot(),
+			"owner":     lock.GetOwner(),
+			"depth":     depth,
+			"scope":     lock.GetScope(),
+			"expiresAt": expiresAt,
+			"token":     lock.GetToken(),
+		})
+	}
+	return lockMaps
+}
@@ -0,0 +1,99 @@
+package app
+
+import (
+	"app/audit"
+	"app/class"
+	"app/util"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+/*
+요청과 요청 중 확인한 권한을 감사 기록기에 기록
+감사 기록기가 없으면 무시
+*/
+func (s *ResourceManagerServer) writeAuditRecord(req *http.Request, status int, checks []audit.Check) {
+	auditLogger := s.getResourceManager(req).GetAuditLogger()
+	if auditLogger == nil {
+		return
+	}
+
+	record := audit.Record{
+		Type:      audit.RECORD_TYPE_REQUEST,
+		RequestId: util.GetRequestId(req.Context()),
+		User:      req.Header.Get("User-Name"),
+		Group:     req.Header.Get("Group-Name"),
+		Method:    req.Method,
+		Path:      req.URL.Path,
+		Status:    status,
+	}
+	record.SetChecks(checks)
+	if err := auditLogger.Log(record); err != nil {
+		s.getLogger().ErrorContext(req.Context(), "감사 기록에 오류가 발생했습니다", slog.Any("error", err))
+	}
+}
+
+/*
+요청한 유저 또는 그룹이 리소스에 권한을 가지고 있는지 확인하고 감사 기록에 추가
+요청을 허용하거나 거부하는 확인에만 사용하며, 목록에서 제외할 리소스를 거를 때는 CheckPermission 사용
+리소스를 찾은 View 안에서 호출
+*/
+func (s *ResourceManagerServer) checkPermission(req *http.Request, resourceObject *class.ResourceObject, permission string) bool {
+	username := req.Header.Get("User-Name")
+	groupname := req.Header.Get("Group-Name")
+	explanation := resourceObject.ExplainPermission(username, groupname, permission)
+
+	if state := getRequestState(req); state != nil {
+		check := audit.Check{
+			Path:       resourceObject.GetPath(),
+			Permission: permission,
+			Decision:   audit.DECISION_DENY,
+			Reason:     getPermissionReason(explanation, username, groupname),
+		}
+		if explanation.IsGranted {
+			check.Decision = audit.DECISION_ALLOW
+		}
+		state.checks = append(state.checks, check)
+	}
+	return explanation.IsGranted
+}
+
+/*
+요청한 유저가 관리자인지 확인하고 감사 기록에 추가
+*/
+func (s *ResourceManagerServer) checkAdmin(req *http.Request) bool {
+	isAdmin := false
+	s.getResourceManager(req).View(func(m *class.ResourceManager) {
+		isAdmin = s.checkPermission(req, m.GetResourceObject("/"), class.PERMISSION_ALL)
+	})
+	return isAdmin
+}
+
+/*
+권한 확인 과정을 감사 기록의 사유로 변환
+허용되었으면 권한을 부여한 항목, 거부되었으면 확인한 유저와 그룹
+*/
+func getPermissionReason(explanation class.PermissionExplanation, username string, groupname string) string {
+	for _, step := range explanation.Steps {
+		if step.IsGranted {
+			return fmt.Sprintf("%s %s의 \"%s\" 항목이 권한을 부여함", step.PrincipalType, step.Principal, step.Entry)
+		}
+	}
+
+	principals := []string{}
+	if username != "" {
+		principals = append(principals, class.PRINCIPAL_TYPE_USER+" "+username)
+	}
+	if groupname != "" {
+		principals = append(principals, class.PRINCIPAL_TYPE_GROUP+" "+groupname)
+	}
+	if len(principals) == 0 {
+		return "유저와 그룹이 없음"
+	}
+	if explanation.Permission == class.PERMISSION_ALL {
+		return fmt.Sprintf("%s에게 \"%s\" 항목이 없음", strings.Join(principals, ", "), class.PERMISSION_ALL)
+	}
+	return fmt.Sprintf("%s에게 \"%s\" 또는 \"%s\" 항목이 없음", strings.Join(principals, ", "), explanation.Permission, class.PERMISSION_ALL)
+}
@@ -0,0 +1,69 @@
+package app
+
+import (
+	"app/util"
+	"errors"
+	"fmt"
+)
+
+const (
+	// 요청의 User-Name, Group-Name 헤더를 그대로 신뢰, 앞단의 프록시가 인증할 때 사용
+	AUTH_PROVIDER_HEADER = "header"
+)
+
+var (
+	ErrInvalidAuthProvider = errors.New("알 수 없는 인증 방법입니다")
+)
+
+/*
+인증 설정
+*/
+type AuthParam struct {
+	// 사용할 인증 방법 목록, 비어있으면 AUTH_PROVIDER_HEADER
+	Providers []string
+}
+
+/*
+인증 설정이 올바른지 확인
+*/
+func (p AuthParam) Validate() error {
+	for _, provider := range p.Providers {
+		if provider != AUTH_PROVIDER_HEADER {
+			return fmt.Errorf("%w: %s", ErrInvalidAuthProvider, provider)
+		}
+	}
+	return nil
+}
+
+/*
+인증 방법 목록 반환
+*/
+func (p AuthParam) getProviders() []string {
+	if len(p.Providers) == 0 {
+		return []string{AUTH_PROVIDER_HEADER}
+	}
+	return p.Providers
+}
+
+/*
+서버의 인증 설정 변경
+처리 중인 요청에는 영향을 주지 않으며, 설정이 올바르지 않으면 변경하지 않고 error 반환
+*/
+func (s *ResourceManagerServer) SetAuthParam(param AuthParam) error {
+	if err := param.Validate(); err != nil {
+		return err
+	}
+
+	s.settingsMutex.Lock()
+	defer s.settingsMutex.Unlock()
+	s.authParam = AuthParam{
+		Providers: util.CloneSlice(param.Providers),
+	}
+	return nil
+}
+
+func (s *ResourceManagerServer) getAuthParam() AuthParam {
+	s.settingsMutex.RLock()
+	defer s.settingsMutex.RUnlock()
+	return s.authParam
+}
@@ -0,0 +1,76 @@
+package app
+
+import (
+	"app/class"
+	"net/http"
+	"strings"
+	"time"
+)
+
+/*
+조건부 요청 헤더(`If-Match`, `If-None-Match`, `If-Modified-Since`) 확인
+resourceObject는 요청 경로의 리소스이며 없으면 nil, 리소스를 찾은 View 안에서 호출
+  - @return {int} 조건을 만족하지 않으면 응답 코드(GET, HEAD는 304, 나머지는 412), 만족하면 0
+*/
+func checkPreconditions(req *http.Request, resourceObject *class.ResourceObject) int {
+	isReadMethod := req.Method == "GET" || req.Method == "HEAD"
+	etag := ""
+	modifiedAt := time.Time{}
+	if resourceObject != nil {
+		etag = resourceObject.GetETag()
+		modifiedAt = resourceObject.GetModifiedAt()
+	}
+
+	ifMatch := strings.Join(req.Header.Values("If-Match"), ",")
+	if ifMatch != "" {
+		if resourceObject == nil || !matchEtag(ifMatch, etag, true) {
+			return 412
+		}
+	}
+
+	ifNoneMatch := strings.Join(req.Header.Values("If-None-Match"), ",")
+	if ifNoneMatch != "" {
+		if resourceObject != nil && matchEtag(ifNoneMatch, etag, false) {
+			if isReadMethod {
+				return 304
+			}
+			return 412
+		}
+		return 0
+	}
+
+	// If-None-Match가 없을 때만 확인
+	if isReadMethod && resourceObject != nil {
+		since, err := http.ParseTime(req.Header.Get("If-Modified-Since"))
+		if err == nil && !modifiedAt.Truncate(time.Second).After(since) {
+			return 304
+		}
+	}
+	return 0
+}
+
+/*
+ETag 목록 헤더에 ETag가 포함되는지 여부 반환
+`*`는 모든 ETag와 일치
+  - @param {bool} isStrong true이면 weak ETag는 일치하지 않는 것으로 처리 (If-Match)
+*/
+func matchEtag(header string, etag string, isStrong bool) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" {
+			return true
+		}
+
+		if isStrong {
+			if strings.HasPrefix(candidate, "W/") || strings.HasPrefix(etag, "W/") {
+				continue
+			}
+			if candidate == etag {
+				return true
+			}
+		} else if strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
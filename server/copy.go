@@ -0,0 +1,77 @@
+package app
+
+import (
+	"app/class"
+	"errors"
+	"net/http"
+)
+
+/*
+COPY: 리소스와 모든 하위 리소스를 `Destination` 헤더의 경로로 복사
+`Depth: 0`이면 폴더만 복사
+*/
+func (s *ResourceManagerServer) handleCopy(res http.ResponseWriter, req *http.Request) {
+	username := req.Header.Get("User-Name")
+	groupname := req.Header.Get("Group-Name")
+	path := getRequestPath(req)
+
+	destinationPath, ok := getDestinationPath(req)
+	if !ok || isSnapshotPath(destinationPath) {
+		res.WriteHeader(400)
+		return
+	}
+	isDepthInfinity := true
+	switch req.Header.Get("Depth") {
+	case "", "infinity":
+		isDepthInfinity = true
+	case "0":
+		isDepthInfinity = false
+	default:
+		res.WriteHeader(400)
+		return
+	}
+	isOverwrite := req.Header.Get("Overwrite") != "F"
+
+	statusCode := 0
+	s.getResourceManager(req).View(func(m *class.ResourceManager) {
+		resourceObject := m.GetResourceObject(path)
+		if resourceObject == nil {
+			statusCode = 404
+			return
+		}
+		if path == destinationPath {
+			statusCode = 403
+			return
+		}
+		if statusCode = checkPreconditions(req, resourceObject); statusCode != 0 {
+			return
+		}
+
+		// 권한 확인
+		if !s.checkPermission(req, resourceObject, "read") {
+			statusCode = 403
+			return
+		}
+		statusCode = s.checkDestination(req, m, destinationPath, isOverwrite)
+	})
+	if statusCode != 0 {
+		res.WriteHeader(statusCode)
+		return
+	}
+
+	// 리소스 복사
+	isOverwritten, err := s.getResourceManager(req).CopyResourceWithLockTokens(path, destinationPath, isOverwrite, isDepthInfinity, username, groupname, getSubmittedLockTokens(req))
+	if err != nil {
+		if errors.Is(err, class.ErrAlreadyExists) {
+			res.WriteHeader(412)
+		} else {
+			res.WriteHeader(getErrorStatusCode(err))
+		}
+		return
+	}
+	if isOverwritten {
+		res.WriteHeader(204)
+	} else {
+		res.WriteHeader(201)
+	}
+}
@@ -0,0 +1,118 @@
+package app
+
+import (
+	"app/class"
+	"app/util"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// 연결이 끊기지 않도록 주석을 보내는 간격
+const eventKeepAliveInterval = 15 * time.Second
+
+/*
+/_events: 트리 변경 이벤트를 Server-Sent Events로 전달(GET)
+`path` 쿼리가 있으면 해당 경로 아래의 리소스에 대한 이벤트만 전달
+요청한 유저가 이벤트 발생 시점에 "read" 권한이 없던 리소스의 이벤트는 전달하지 않음
+`Last-Event-ID` 헤더가 있으면 이후 놓친 이벤트를 먼저 전달하며,
+보관한 이벤트로 이어서 전달할 수 없으면 "reset" 이벤트를 전달
+*/
+func (s *ResourceManagerServer) handleEvents(res http.ResponseWriter, req *http.Request) {
+	if req.Method != "GET" {
+		res.Header().Set("Allow", "GET")
+		res.WriteHeader(405)
+		return
+	}
+
+	username := req.Header.Get("User-Name")
+	groupname := req.Header.Get("Group-Name")
+
+	path := req.URL.Query().Get("path")
+	if path == "" {
+		path = "/"
+	}
+	if !util.IsValidPath(path) {
+		res.WriteHeader(400)
+		return
+	}
+	lastEventId := int64(-1)
+	if value := req.Header.Get("Last-Event-ID"); value != "" {
+		var err error
+		lastEventId, err = strconv.ParseInt(value, 10, 64)
+		if err != nil || lastEventId < 0 {
+			res.WriteHeader(400)
+			return
+		}
+	}
+	flusher, ok := res.(http.Flusher)
+	if !ok {
+		res.WriteHeader(500)
+		return
+	}
+
+	subscription, missedEvents, ok := s.getResourceManager(req).GetEventBus().Subscribe(lastEventId)
+	defer subscription.Close()
+
+	res.Header().Set("Content-Type", "text/event-stream")
+	res.Header().Set("Cache-Control", "no-cache")
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(200)
+
+	if !ok {
+		writeResetEvent(res, subscription.GetStartId())
+	}
+	for _, event := range missedEvents {
+		if event.IsUnder(path) && event.CanRead(username, groupname) {
+			writeEvent(res, event)
+		}
+	}
+	flusher.Flush()
+
+	ticker := time.NewTicker(eventKeepAliveInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-req.Context().Done():
+			return
+		case <-s.closing:
+			// 서버를 종료하면 연결을 닫아 Last-Event-ID로 다시 연결하도록 함
+			return
+		case event, ok := <-subscription.GetChannel():
+			// 이벤트를 받지 못해 구독이 끊기면 연결을 닫아 Last-Event-ID로 다시 연결하도록 함
+			if !ok {
+				return
+			}
+			if !event.IsUnder(path) || !event.CanRead(username, groupname) {
+				continue
+			}
+			writeEvent(res, event)
+			flusher.Flush()
+		case <-ticker.C:
+			fmt.Fprint(res, ": keep-alive\n\n")
+			flusher.Flush()
+		}
+	}
+}
+
+/*
+이벤트를 Server-Sent Events 형식으로 출력
+*/
+func writeEvent(w io.Writer, event class.ResourceEvent) {
+	data, _ := json.Marshal(event.ToMap())
+	fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.GetId(), event.GetType(), data)
+}
+
+/*
+놓친 이벤트를 다시 보낼 수 없음을 알리는 이벤트 출력
+받은 클라이언트는 트리를 다시 조회해야 함
+*/
+func writeResetEvent(w io.Writer, lastEventId int64) {
+	data, _ := json.Marshal(map[string]any{
+		"lastEventId": lastEventId,
+	})
+	fmt.Fprintf(w, "id: %d\nevent: reset\ndata: %s\n\n", lastEventId, data)
+}
@@ -0,0 +1,84 @@
+package app
+
+import (
+	"app/class"
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+/*
+/_admin/export?path=: 경로의 리소스와 하위 리소스를 내보내기(GET)
+*/
+func (s *ResourceManagerServer) handleAdminExport(res http.ResponseWriter, req *http.Request) {
+	if !s.checkAdmin(req) {
+		res.WriteHeader(403)
+		return
+	}
+	if req.Method != "GET" {
+		res.Header().Set("Allow", "GET")
+		res.WriteHeader(405)
+		return
+	}
+
+	path := req.URL.Query().Get("path")
+	if path == "" {
+		path = "/"
+	}
+	exportMap, err := s.getResourceManager(req).ExportResource(path)
+	if err != nil {
+		res.WriteHeader(getErrorStatusCode(err))
+		return
+	}
+	writeJson(res, 200, exportMap)
+}
+
+/*
+/_admin/import?path=: 내보낸 리소스를 경로로 가져오기(POST)
+경로에 리소스가 있으면 `Overwrite: T`일 때만 덮어씀
+*/
+func (s *ResourceManagerServer) handleAdminImport(res http.ResponseWriter, req *http.Request) {
+	if !s.checkAdmin(req) {
+		res.WriteHeader(403)
+		return
+	}
+	if req.Method != "POST" {
+		res.Header().Set("Allow", "POST")
+		res.WriteHeader(405)
+		return
+	}
+
+	path := req.URL.Query().Get("path")
+	if path == "" {
+		res.WriteHeader(400)
+		return
+	}
+	var exportMap map[string]any
+	if err := json.NewDecoder(req.Body).Decode(&exportMap); err != nil {
+		res.WriteHeader(400)
+		return
+	}
+
+	isOverwritten, err := s.getResourceManager(req).ImportResourceWithLockTokens(
+		path,
+		exportMap,
+		req.Header.Get("Overwrite") == "T",
+		req.Header.Get("User-Name"),
+		getSubmittedLockTokens(req),
+	)
+	if err != nil {
+		if errors.Is(err, class.ErrAlreadyExists) {
+			res.WriteHeader(412)
+		} else {
+			res.WriteHeader(getErrorStatusCode(err))
+		}
+		return
+	}
+
+	res.Header().Set("Location", path)
+	if isOverwritten {
+		res.WriteHeader(204)
+	} else {
+		res.WriteHeader(201)
+	}
+}
@@ -0,0 +1,148 @@
+package app
+
+import (
+	"app/class"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"time"
+)
+
+/*
+GET: 파일 내용 또는 폴더의 하위 리소스 목록 조회
+"read" 권한이 없는 하위 리소스는 목록에서 제외
+*/
+func (s *ResourceManagerServer) handleGet(res http.ResponseWriter, req *http.Request) {
+	path := getRequestPath(req)
+
+	// 버전 목록 또는 특정 버전의 내용 조회
+	query := req.URL.Query()
+	if query.Has("versions") {
+		s.writeVersions(res, req, path)
+		return
+	}
+	if query.Has("version") {
+		s.writeVersionContent(res, req, path)
+		return
+	}
+
+	// 응답에 필요한 값은 잠금을 잡은 동안 모두 읽고, 응답은 잠금을 해제한 후 작성
+	statusCode := 0
+	etag := ""
+	modifiedAt := time.Time{}
+	var content io.ReadSeekCloser
+	var listing map[string]any
+	s.getResourceManager(req).View(func(m *class.ResourceManager) {
+		resourceObject, readStatusCode := s.getReadableResourceObject(req, m, path)
+		if resourceObject == nil {
+			statusCode = readStatusCode
+			return
+		}
+
+		etag = resourceObject.GetETag()
+		modifiedAt = resourceObject.GetModifiedAt()
+		if statusCode = checkPreconditions(req, resourceObject); statusCode != 0 {
+			return
+		}
+
+		if resourceObject.IsFile() {
+			var err error
+			if content, err = m.OpenContent(path); err != nil {
+				statusCode = 500
+			}
+			return
+		}
+		listing = getListingMap(req, resourceObject)
+	})
+	if etag != "" || !modifiedAt.IsZero() {
+		res.Header().Set("ETag", etag)
+		res.Header().Set("Last-Modified", modifiedAt.UTC().Format(http.TimeFormat))
+	}
+	if statusCode != 0 {
+		res.WriteHeader(statusCode)
+		return
+	}
+
+	if content != nil {
+		defer content.Close()
+		_, name := filepath.Split(path)
+		serveContent(res, req, name, modifiedAt, content)
+		return
+	}
+
+	if req.Method == "HEAD" {
+		res.Header().Set("Content-Type", "application/json; charset=utf-8")
+		res.WriteHeader(200)
+		return
+	}
+	writeJson(res, 200, listing)
+}
+
+/*
+경로에서 "read" 권한이 있는 리소스 반환
+View 안에서 호출
+  - @return {*class.ResourceObject} 리소스, 실패시 nil
+  - @return {int} 실패시 응답 코드 (리소스가 없으면 404, 권한이 없으면 403)
+*/
+func (s *ResourceManagerServer) getReadableResourceObject(req *http.Request, m *class.ResourceManager, path string) (*class.ResourceObject, int) {
+	resourceObject := m.GetResourceObject(path)
+	if resourceObject == nil {
+		return nil, 404
+	}
+
+	// 권한 확인
+	if !s.checkPermission(req, resourceObject, "read") {
+		return nil, 403
+	}
+	return resourceObject, 0
+}
+
+/*
+폴더와 "read" 권한이 있는 하위 리소스의 메타데이터를 응답용 map으로 변환
+View 안에서 호출
+*/
+func getListingMap(req *http.Request, resourceObject *class.ResourceObject) map[string]any {
+	username := req.Header.Get("User-Name")
+	groupname := req.Header.Get("Group-Name")
+
+	children := []map[string]any{}
+	for _, child := range resourceObject.GetChildren() {
+		if !child.CheckPermission(username, groupname, "read") {
+			continue
+		}
+		children = append(children, getResourceMetadataMap(child))
+	}
+	listing := getResourceMetadataMap(resourceObject)
+	listing["children"] = children
+	return listing
+}
+
+/*
+내용 응답
+`Range`, `If-Range` 헤더가 있으면 206 부분 응답, 여러 범위는 multipart/byteranges로 응답
+*/
+func serveContent(res http.ResponseWriter, req *http.Request, name string, modifiedAt time.Time, content io.ReadSeeker) {
+	contentType := mime.TypeByExtension(filepath.Ext(name))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	res.Header().Set("Content-Type", contentType)
+	http.ServeContent(res, req, name, modifiedAt, content)
+}
+
+/*
+리소스의 메타데이터를 응답용 map으로 변환
+*/
+func getResourceMetadataMap(resourceObject *class.ResourceObject) map[string]any {
+	return map[string]any{
+		"path":         resourceObject.GetPath(),
+		"name":         resourceObject.GetName(),
+		"isDirectory":  resourceObject.IsDirectory(),
+		"size":         resourceObject.GetSize(),
+		"createdAt":    resourceObject.GetCreatedAt().UTC().Format(time.RFC3339),
+		"modifiedAt":   resourceObject.GetModifiedAt().UTC().Format(time.RFC3339),
+		"creator":      resourceObject.GetCreator(),
+		"lastModifier": resourceObject.GetLastModifier(),
+	}
+}
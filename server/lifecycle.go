@@ -0,0 +1,192 @@
+package app
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+)
+
+// Start의 ctx가 끝났을 때 처리 중인 요청을 기다리는 기본 시간
+const DEFAULT_SHUTDOWN_TIMEOUT = 30 * time.Second
+
+var (
+	ErrServerStarted = errors.New("서버가 이미 시작되었습니다")
+	ErrServerClosed  = errors.New("서버가 종료되었습니다")
+)
+
+/*
+서버 시작 설정
+*/
+type ListenParam struct {
+	// "{host}:{port}" 형식, host가 비어있으면 모든 주소, port가 0이면 사용하지 않는 포트를 선택
+	Address string
+	// 인증서, 개인 키 파일 경로, 둘 다 지정하면 TLS 서버로 시작
+	CertFile string
+	KeyFile  string
+	// Start의 ctx가 끝났을 때 처리 중인 요청을 기다리는 최대 시간, 0 이하이면 DEFAULT_SHUTDOWN_TIMEOUT
+	ShutdownTimeout time.Duration
+}
+
+/*
+서버 시작
+주소에 연결한 후 요청은 별도의 goroutine에서 처리하며, 연결에 실패하면 error 반환
+ctx가 끝나면 param.ShutdownTimeout 동안 처리 중인 요청을 기다린 후 종료
+*/
+func (s *ResourceManagerServer) Start(ctx context.Context, param ListenParam) error {
+	s.lifecycleMutex.Lock()
+	defer s.lifecycleMutex.Unlock()
+	if s.isShutdown {
+		return ErrServerClosed
+	}
+	if s.httpServer != nil {
+		return ErrServerStarted
+	}
+
+	httpServer := &http.Server{
+		Handler:  s,
+		ErrorLog: slog.NewLogLogger(s.getLogger().Handler(), slog.LevelWarn),
+	}
+	isTLS := param.CertFile != "" || param.KeyFile != ""
+	if isTLS {
+		// 인증서 오류를 시작할 때 반환하기 위해 미리 불러옴
+		certificate, err := tls.LoadX509KeyPair(param.CertFile, param.KeyFile)
+		if err != nil {
+			return err
+		}
+		httpServer.TLSConfig = &tls.Config{Certificates: []tls.Certificate{certificate}}
+	}
+	listener, err := net.Listen("tcp", param.Address)
+	if err != nil {
+		return err
+	}
+	s.httpServer = httpServer
+	s.listener = listener
+
+	if isTLS {
+		s.getLogger().Info("TLS 서버를 시작합니다", slog.String("address", listener.Addr().String()))
+	} else {
+		s.getLogger().Info("서버를 시작합니다", slog.String("address", listener.Addr().String()))
+	}
+	go func() {
+		var err error
+		if isTLS {
+			err = httpServer.ServeTLS(listener, "", "")
+		} else {
+			err = httpServer.Serve(listener)
+		}
+		if err == nil || errors.Is(err, http.ErrServerClosed) {
+			return
+		}
+		// 요청을 받지 못하게 되면 종료 절차를 진행
+		s.getLogger().Error("서버 실행에 오류가 발생했습니다", slog.Any("error", err))
+		s.lifecycleMutex.Lock()
+		s.serveErr = err
+		s.lifecycleMutex.Unlock()
+		s.Shutdown(context.Background())
+	}()
+
+	shutdownTimeout := param.ShutdownTimeout
+	if shutdownTimeout <= 0 {
+		shutdownTimeout = DEFAULT_SHUTDOWN_TIMEOUT
+	}
+	go func() {
+		select {
+		case <-s.stopped:
+		case <-ctx.Done():
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+			defer cancel()
+			s.Shutdown(shutdownCtx)
+		}
+	}()
+	return nil
+}
+
+/*
+서버가 연결한 주소 반환, 시작하지 않았으면 nil
+*/
+func (s *ResourceManagerServer) Addr() net.Addr {
+	s.lifecycleMutex.Lock()
+	defer s.lifecycleMutex.Unlock()
+	if s.listener == nil {
+		return nil
+	}
+	return s.listener.Addr()
+}
+
+/*
+종료할 때 처리 중인 요청을 모두 처리한 후 실행할 함수 등록
+등록한 순서대로 실행하며, 트리 상태 저장처럼 마지막 요청까지 반영해야 하는 작업에 사용
+*/
+func (s *ResourceManagerServer) RegisterOnShutdown(fn func(ctx context.Context) error) {
+	s.lifecycleMutex.Lock()
+	defer s.lifecycleMutex.Unlock()
+	s.shutdownHooks = append(s.shutdownHooks, fn)
+}
+
+/*
+서버 종료
+새 연결을 받지 않고 이벤트 스트림을 닫은 후 처리 중인 요청이 끝날 때까지 대기
+ctx가 끝나면 남은 연결을 강제로 닫음
+이후 RegisterOnShutdown으로 등록한 함수를 실행하고 웹훅 전송기를 닫음
+이미 종료 중이면 종료가 끝날 때까지 기다린 후 같은 결과 반환
+*/
+func (s *ResourceManagerServer) Shutdown(ctx context.Context) error {
+	s.lifecycleMutex.Lock()
+	if s.isShutdown {
+		s.lifecycleMutex.Unlock()
+		<-s.stopped
+		return s.getShutdownErr()
+	}
+	s.isShutdown = true
+	httpServer := s.httpServer
+	shutdownHooks := s.shutdownHooks
+	s.lifecycleMutex.Unlock()
+
+	s.getLogger().Info("서버를 종료합니다")
+	close(s.closing)
+	errs := []error{}
+	if httpServer != nil {
+		if err := httpServer.Shutdown(ctx); err != nil {
+			s.getLogger().Warn("처리 중인 요청을 기다리지 못하고 연결을 닫습니다", slog.Any("error", err))
+			httpServer.Close()
+			errs = append(errs, err)
+		}
+	}
+	for _, hook := range shutdownHooks {
+		if err := hook(ctx); err != nil {
+			s.getLogger().Error("종료 작업에 실패했습니다", slog.Any("error", err))
+			errs = append(errs, err)
+		}
+	}
+	if s.webhooks != nil {
+		s.webhooks.Close()
+	}
+
+	s.lifecycleMutex.Lock()
+	s.shutdownErr = errors.Join(errs...)
+	s.lifecycleMutex.Unlock()
+	close(s.stopped)
+	s.getLogger().Info("서버를 종료했습니다")
+	return s.getShutdownErr()
+}
+
+/*
+서버가 종료될 때까지 대기
+  - @return {error} 요청을 받지 못하게 된 오류 또는 종료 중 발생한 오류, Shutdown으로 정상 종료하면 nil
+*/
+func (s *ResourceManagerServer) Wait() error {
+	<-s.stopped
+	s.lifecycleMutex.Lock()
+	defer s.lifecycleMutex.Unlock()
+	return errors.Join(s.serveErr, s.shutdownErr)
+}
+
+func (s *ResourceManagerServer) getShutdownErr() error {
+	s.lifecycleMutex.Lock()
+	defer s.lifecycleMutex.Unlock()
+	return s.shutdownErr
+}
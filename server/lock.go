@@ -0,0 +1,225 @@
+package app
+
+import (
+	"app/class"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+/*
+잠금 만료 시간 정책
+*/
+type LockTimeoutPolicy struct {
+	// `Timeout` 헤더가 없거나 `Infinite`일 때 사용할 만료 시간, 0이면 만료되지 않음
+	DefaultTimeout time.Duration
+	// 요청할 수 있는 최대 만료 시간, 0이면 제한 없음
+	MaxTimeout time.Duration
+}
+
+/*
+요청한 만료 시간에 정책을 적용
+  - @param {time.Duration} timeout 요청한 만료 시간, 0이면 만료되지 않음
+*/
+func (p LockTimeoutPolicy) apply(timeout time.Duration) time.Duration {
+	if timeout <= 0 {
+		timeout = p.DefaultTimeout
+	}
+	if p.MaxTimeout > 0 && (timeout <= 0 || timeout > p.MaxTimeout) {
+		timeout = p.MaxTimeout
+	}
+	return timeout
+}
+
+/*
+서버의 잠금 만료 시간 정책 변경
+이미 걸린 잠금의 만료 시간은 바꾸지 않음
+*/
+func (s *ResourceManagerServer) SetLockTimeoutPolicy(policy LockTimeoutPolicy) {
+	s.settingsMutex.Lock()
+	defer s.settingsMutex.Unlock()
+	s.lockTimeoutPolicy = policy
+}
+
+/*
+요청의 `Timeout` 헤더에 정책을 적용한 잠금 만료 시간 반환
+*/
+func (s *ResourceManagerServer) getLockTimeout(req *http.Request) time.Duration {
+	s.settingsMutex.RLock()
+	defer s.settingsMutex.RUnlock()
+	return s.lockTimeoutPolicy.apply(getLockTimeout(req))
+}
+
+/*
+LOCK: 경로의 리소스 잠금 또는 잠금 갱신
+*/
+func (s *ResourceManagerServer) handleLock(res http.ResponseWriter, req *http.Request) {
+	username := req.Header.Get("User-Name")
+	groupname := req.Header.Get("Group-Name")
+	path := getRequestPath(req)
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		res.WriteHeader(400)
+		return
+	}
+
+	// 요청 본문이 없으면 잠금 갱신
+	if len(body) == 0 {
+		s.refreshLock(res, req)
+		return
+	}
+
+	var lockInfo davLockInfo
+	if err := xml.Unmarshal(body, &lockInfo); err != nil {
+		res.WriteHeader(400)
+		return
+	}
+	scope := class.LOCK_SCOPE_EXCLUSIVE
+	if lockInfo.LockScope.Shared != nil {
+		scope = class.LOCK_SCOPE_SHARED
+	}
+
+	isDepthInfinity := true
+	switch req.Header.Get("Depth") {
+	case "", "infinity":
+		isDepthInfinity = true
+	case "0":
+		isDepthInfinity = false
+	default:
+		res.WriteHeader(400)
+		return
+	}
+
+	// 리소스가 없으면 빈 파일을 생성한 뒤 잠금
+	// 생성한 파일은 부모 폴더의 권한을 상속하므로 생성하기 전에 부모 폴더의 "lock" 권한도 확인
+	isExist := false
+	statusCode := 0
+	s.getResourceManager(req).View(func(m *class.ResourceManager) {
+		resourceObject := m.GetResourceObject(path)
+		if resourceObject != nil {
+			isExist = true
+			if !s.checkPermission(req, resourceObject, "lock") {
+				statusCode = 403
+			}
+			return
+		}
+		parentResourceObject, parentStatusCode := getParentResourceObject(m, path)
+		if parentResourceObject == nil {
+			statusCode = parentStatusCode
+			return
+		}
+		if !s.checkPermission(req, parentResourceObject, "write") ||
+			!s.checkPermission(req, parentResourceObject, "lock") {
+			statusCode = 403
+		}
+	})
+	if statusCode != 0 {
+		res.WriteHeader(statusCode)
+		return
+	}
+
+	statusCode = 200
+	if !isExist {
+		if _, err := s.getResourceManager(req).CreateResourceWithLockTokens(path, false, true, username, groupname, getSubmittedLockTokens(req)); err != nil {
+			res.WriteHeader(getErrorStatusCode(err))
+			return
+		}
+		statusCode = 201
+	}
+
+	// 리소스 잠금, 실패하면 생성한 빈 파일 삭제
+	// 잠금을 확인하며 삭제하므로 그 사이 다른 요청이 잠근 파일은 삭제하지 않음
+	success, lockToken := s.getResourceManager(req).LockWithParam(path, class.LockParam{
+		IsDepthInfinity: isDepthInfinity,
+		Owner:           username,
+		Scope:           scope,
+		Timeout:         s.getLockTimeout(req),
+	})
+	if !success {
+		if statusCode == 201 {
+			s.getResourceManager(req).DeleteResourceWithLockTokens(path, username, getSubmittedLockTokens(req))
+		}
+		res.WriteHeader(423)
+		return
+	}
+
+	res.Header().Set("Lock-Token", "<opaquelocktoken:"+lockToken+">")
+	s.writeLockDiscovery(res, req, statusCode, path, lockToken)
+}
+
+/*
+`If` 헤더로 제출된 잠금 토큰의 만료 시간 갱신
+*/
+func (s *ResourceManagerServer) refreshLock(res http.ResponseWriter, req *http.Request) {
+	path := getRequestPath(req)
+
+	for _, lockToken := range getSubmittedLockTokens(req) {
+		if s.getResourceManager(req).RefreshLock(path, lockToken, s.getLockTimeout(req)) {
+			s.writeLockDiscovery(res, req, 200, path, lockToken)
+			return
+		}
+	}
+
+	isExist := false
+	s.getResourceManager(req).View(func(m *class.ResourceManager) {
+		isExist = m.GetResourceObject(path) != nil
+	})
+	if !isExist {
+		res.WriteHeader(404)
+		return
+	}
+	res.WriteHeader(412)
+}
+
+/*
+해당 토큰의 잠금 정보를 lockdiscovery 속성으로 응답
+*/
+func (s *ResourceManagerServer) writeLockDiscovery(res http.ResponseWriter, req *http.Request, statusCode int, path string, lockToken string) {
+	locks := []class.ResourceLock{}
+	s.getResourceManager(req).View(func(m *class.ResourceManager) {
+		resourceObject := m.GetResourceObject(path)
+		if resourceObject == nil {
+			return
+		}
+		for _, lock := range resourceObject.GetLocks() {
+			if lock.GetToken() == lockToken {
+				locks = append(locks, lock)
+			}
+		}
+	})
+
+	writeXml(res, statusCode, davProp{
+		XmlnsD:     "DAV:",
+		Properties: []davProperty{newDavLockDiscovery(locks)},
+	})
+}
+
+/*
+UNLOCK: `Lock-Token` 헤더로 제출된 잠금 해제
+*/
+func (s *ResourceManagerServer) handleUnlock(res http.ResponseWriter, req *http.Request) {
+	path := getRequestPath(req)
+	lockToken := trimLockToken(strings.Trim(req.Header.Get("Lock-Token"), "<>"))
+	if lockToken == "" {
+		res.WriteHeader(400)
+		return
+	}
+
+	isExist := false
+	s.getResourceManager(req).View(func(m *class.ResourceManager) {
+		isExist = m.GetResourceObject(path) != nil
+	})
+	if !isExist {
+		res.WriteHeader(404)
+		return
+	}
+
+	if !s.getResourceManager(req).Unlock(path, lockToken) {
+		res.WriteHeader(409)
+		return
+	}
+	res.WriteHeader(204)
+}
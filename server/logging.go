@@ -0,0 +1,91 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+const (
+	LOG_FORMAT_TEXT = "text"
+	LOG_FORMAT_JSON = "json"
+)
+
+type LogParam struct {
+	// "debug", "info", "warn", "error", 비어있으면 "info"
+	Level string
+	// LOG_FORMAT_TEXT 또는 LOG_FORMAT_JSON, 비어있으면 LOG_FORMAT_TEXT
+	Format string
+	// 설정하면 Level로 초기화하여 로그 수준으로 사용, 실행 중 로그 수준을 바꿀 때 사용
+	LevelVar *slog.LevelVar
+}
+
+/*
+로그 수준과 형식을 지정하여 slog.Logger 생성
+수준이나 형식이 올바르지 않으면 error 반환
+*/
+func NewLogger(output io.Writer, param LogParam) (*slog.Logger, error) {
+	level, err := ParseLogLevel(param.Level)
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateLogFormat(param.Format); err != nil {
+		return nil, err
+	}
+
+	options := &slog.HandlerOptions{Level: level}
+	if param.LevelVar != nil {
+		param.LevelVar.Set(level)
+		options.Level = param.LevelVar
+	}
+	if strings.ToLower(param.Format) == LOG_FORMAT_JSON {
+		return slog.New(slog.NewJSONHandler(output, options)), nil
+	}
+	return slog.New(slog.NewTextHandler(output, options)), nil
+}
+
+/*
+로그 수준 문자열을 slog.Level로 변환
+빈 문자열이면 slog.LevelInfo, 올바르지 않으면 error 반환
+*/
+func ParseLogLevel(value string) (slog.Level, error) {
+	var level slog.Level
+	if value == "" {
+		return level, nil
+	}
+	if err := level.UnmarshalText([]byte(value)); err != nil {
+		return level, fmt.Errorf("올바르지 않은 로그 수준입니다: %s", value)
+	}
+	return level, nil
+}
+
+/*
+로그 형식이 올바른지 확인
+*/
+func ValidateLogFormat(format string) error {
+	switch strings.ToLower(format) {
+	case "", LOG_FORMAT_TEXT, LOG_FORMAT_JSON:
+		return nil
+	default:
+		return fmt.Errorf("올바르지 않은 로그 형식입니다: %s", format)
+	}
+}
+
+/*
+서버의 로그 기록기 설정
+nil이면 slog.Default() 사용
+*/
+func (s *ResourceManagerServer) SetLogger(logger *slog.Logger) {
+	s.logger = logger
+}
+
+/*
+서버의 로그 기록기 반환
+*/
+func (s *ResourceManagerServer) getLogger() *slog.Logger {
+	if s.logger == nil {
+		return slog.Default()
+	}
+	return s.logger
+}
@@ -0,0 +1,250 @@
+package app
+
+import (
+	"app/audit"
+	"app/class"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 응답 시간 히스토그램의 구간 상한(초)
+var defaultDurationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// 지표에 메소드 이름 그대로 기록하는 메소드, 그 외는 "OTHER"로 기록
+var knownMethods = map[string]bool{
+	"OPTIONS": true, "GET": true, "HEAD": true, "PUT": true, "POST": true, "DELETE": true,
+	"MKCOL": true, "MOVE": true, "COPY": true, "PROPFIND": true, "PROPPATCH": true, "LOCK": true, "UNLOCK": true,
+}
+
+/*
+누적 분포 히스토그램
+*/
+type histogram struct {
+	buckets []float64
+	counts  []int64
+	sum     float64
+	count   int64
+}
+
+func newHistogram(buckets []float64) *histogram {
+	return &histogram{
+		buckets: buckets,
+		counts:  make([]int64, len(buckets)),
+	}
+}
+
+func (h *histogram) observe(value float64) {
+	for i, bucket := range h.buckets {
+		if value <= bucket {
+			h.counts[i]++
+		}
+	}
+	h.sum += value
+	h.count++
+}
+
+/*
+요청 지표의 메소드, 응답 코드 레이블
+*/
+type requestMetricKey struct {
+	method string
+	status string
+}
+
+/*
+서버 지표 저장소
+Prometheus text exposition format으로 출력
+*/
+type metricsRegistry struct {
+	mutex             sync.Mutex
+	requestCounts     map[requestMetricKey]int64
+	requestDurations  map[requestMetricKey]*histogram
+	permissionDenials map[string]int64
+	lockContentions   map[string]int64
+	flushDurations    *histogram
+}
+
+func newMetricsRegistry() *metricsRegistry {
+	return &metricsRegistry{
+		requestCounts:     map[requestMetricKey]int64{},
+		requestDurations:  map[requestMetricKey]*histogram{},
+		permissionDenials: map[string]int64{},
+		lockContentions:   map[string]int64{},
+		flushDurations:    newHistogram(defaultDurationBuckets),
+	}
+}
+
+/*
+처리한 요청을 지표에 반영
+잠금 때문에 거부된 요청(423)은 잠금 경합으로 기록
+*/
+func (m *metricsRegistry) observeRequest(method string, status int, duration time.Duration, checks []audit.Check) {
+	if !knownMethods[method] {
+		method = "OTHER"
+	}
+	key := requestMetricKey{method: method, status: strconv.Itoa(status)}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.requestCounts[key]++
+	if m.requestDurations[key] == nil {
+		m.requestDurations[key] = newHistogram(defaultDurationBuckets)
+	}
+	m.requestDurations[key].observe(duration.Seconds())
+	for _, check := range checks {
+		if check.Decision == audit.DECISION_DENY {
+			m.permissionDenials[check.Permission]++
+		}
+	}
+	if status == 423 {
+		m.lockContentions[method]++
+	}
+}
+
+/*
+트리 상태를 저장하는 데 걸린 시간을 지표에 반영
+*/
+func (s *ResourceManagerServer) ObservePersistenceFlush(duration time.Duration) {
+	s.metrics.mutex.Lock()
+	defer s.metrics.mutex.Unlock()
+	s.metrics.flushDurations.observe(duration.Seconds())
+}
+
+/*
+/metrics: Prometheus text exposition format으로 지표 조회(GET)
+*/
+func (s *ResourceManagerServer) handleMetrics(res http.ResponseWriter, req *http.Request) {
+	if req.Method != "GET" && req.Method != "HEAD" {
+		res.Header().Set("Allow", "GET, HEAD")
+		res.WriteHeader(405)
+		return
+	}
+
+	// 트리 상태와 감사 기록 파일 크기는 조회할 때 계산
+	nodeCounts := map[string]int64{class.RESOURCE_TYPE_FILE: 0, class.RESOURCE_TYPE_DIRECTORY: 0}
+	s.getResourceManager(req).Walk("/", func(resource *class.ResourceObject, depth int) error {
+		if resource.IsDirectory() {
+			nodeCounts[class.RESOURCE_TYPE_DIRECTORY]++
+		} else {
+			nodeCounts[class.RESOURCE_TYPE_FILE]++
+		}
+		return nil
+	})
+	activeLocks := len(s.getResourceManager(req).GetActiveLocks(""))
+	journalSize := int64(0)
+	if auditLogger := s.getResourceManager(req).GetAuditLogger(); auditLogger != nil {
+		journalSize = auditLogger.Size()
+	}
+
+	res.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	res.WriteHeader(200)
+	if req.Method == "HEAD" {
+		return
+	}
+	s.metrics.write(res, nodeCounts, activeLocks, journalSize)
+}
+
+/*
+지표를 Prometheus text exposition format으로 출력
+*/
+func (m *metricsRegistry) write(w io.Writer, nodeCounts map[string]int64, activeLocks int, journalSize int64) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	requestKeys := []requestMetricKey{}
+	for key := range m.requestCounts {
+		requestKeys = append(requestKeys, key)
+	}
+	sort.Slice(requestKeys, func(i, j int) bool {
+		if requestKeys[i].method != requestKeys[j].method {
+			return requestKeys[i].method < requestKeys[j].method
+		}
+		return requestKeys[i].status < requestKeys[j].status
+	})
+
+	writeMetricHeader(w, "resource_server_requests_total", "counter", "처리한 요청 수")
+	for _, key := range requestKeys {
+		fmt.Fprintf(w, "resource_server_requests_total{method=%s,status=%s} %d\n", quoteLabel(key.method), quoteLabel(key.status), m.requestCounts[key])
+	}
+
+	writeMetricHeader(w, "resource_server_request_duration_seconds", "histogram", "요청 처리 시간(초)")
+	for _, key := range requestKeys {
+		labels := fmt.Sprintf("method=%s,status=%s", quoteLabel(key.method), quoteLabel(key.status))
+		writeHistogram(w, "resource_server_request_duration_seconds", labels, m.requestDurations[key])
+	}
+
+	writeMetricHeader(w, "resource_server_permission_denials_total", "counter", "권한이 없어 거부한 권한 확인 수")
+	for _, permission := range sortedKeys(m.permissionDenials) {
+		fmt.Fprintf(w, "resource_server_permission_denials_total{permission=%s} %d\n", quoteLabel(permission), m.permissionDenials[permission])
+	}
+
+	writeMetricHeader(w, "resource_server_lock_contention_total", "counter", "잠금 때문에 거부한 요청 수(423)")
+	for _, method := range sortedKeys(m.lockContentions) {
+		fmt.Fprintf(w, "resource_server_lock_contention_total{method=%s} %d\n", quoteLabel(method), m.lockContentions[method])
+	}
+
+	writeMetricHeader(w, "resource_server_active_locks", "gauge", "트리에 걸린 잠금 수")
+	fmt.Fprintf(w, "resource_server_active_locks %d\n", activeLocks)
+
+	writeMetricHeader(w, "resource_server_nodes", "gauge", "종류별 리소스 수")
+	for _, resourceType := range sortedKeys(nodeCounts) {
+		fmt.Fprintf(w, "resource_server_nodes{type=%s} %d\n", quoteLabel(resourceType), nodeCounts[resourceType])
+	}
+
+	writeMetricHeader(w, "resource_server_persistence_flush_duration_seconds", "histogram", "트리 상태 저장 시간(초)")
+	writeHistogram(w, "resource_server_persistence_flush_duration_seconds", "", m.flushDurations)
+
+	writeMetricHeader(w, "resource_server_journal_size_bytes", "gauge", "현재 감사 기록 파일 크기(byte)")
+	fmt.Fprintf(w, "resource_server_journal_size_bytes %d\n", journalSize)
+}
+
+func writeMetricHeader(w io.Writer, name string, metricType string, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(w, "# TYPE %s %s\n", name, metricType)
+}
+
+/*
+히스토그램의 구간별 누적 개수, 합계, 개수 출력
+labels는 `key="value"` 목록, 없으면 빈 문자열
+*/
+func writeHistogram(w io.Writer, name string, labels string, h *histogram) {
+	prefix := ""
+	if labels != "" {
+		prefix = labels + ","
+	}
+	for i, bucket := range h.buckets {
+		fmt.Fprintf(w, "%s_bucket{%sle=%s} %d\n", name, prefix, quoteLabel(strconv.FormatFloat(bucket, 'g', -1, 64)), h.counts[i])
+	}
+	fmt.Fprintf(w, "%s_bucket{%sle=\"+Inf\"} %d\n", name, prefix, h.count)
+
+	suffix := ""
+	if labels != "" {
+		suffix = "{" + labels + "}"
+	}
+	fmt.Fprintf(w, "%s_sum%s %s\n", name, suffix, strconv.FormatFloat(h.sum, 'g', -1, 64))
+	fmt.Fprintf(w, "%s_count%s %d\n", name, suffix, h.count)
+}
+
+/*
+레이블 값을 따옴표로 감싸고 escape
+*/
+func quoteLabel(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return `"` + replacer.Replace(value) + `"`
+}
+
+func sortedKeys[T any](valueMap map[string]T) []string {
+	keys := []string{}
+	for key := range valueMap {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
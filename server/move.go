@@ -0,0 +1,117 @@
+package app
+
+import (
+	"app/class"
+	"errors"
+	"net/http"
+	"net/url"
+)
+
+/*
+MOVE: 리소스를 `Destination` 헤더의 경로로 이동
+*/
+func (s *ResourceManagerServer) handleMove(res http.ResponseWriter, req *http.Request) {
+	username := req.Header.Get("User-Name")
+	path := getRequestPath(req)
+
+	destinationPath, ok := getDestinationPath(req)
+	if !ok {
+		res.WriteHeader(400)
+		return
+	}
+	// 폴더는 항상 하위 리소스와 함께 이동
+	if depth := req.Header.Get("Depth"); depth != "" && depth != "infinity" {
+		res.WriteHeader(400)
+		return
+	}
+	isOverwrite := req.Header.Get("Overwrite") != "F"
+
+	statusCode := 0
+	s.getResourceManager(req).View(func(m *class.ResourceManager) {
+		resourceObject := m.GetResourceObject(path)
+		if resourceObject == nil {
+			statusCode = 404
+			return
+		}
+		if path == destinationPath {
+			statusCode = 403
+			return
+		}
+		if statusCode = checkPreconditions(req, resourceObject); statusCode != 0 {
+			return
+		}
+
+		// 권한 확인
+		if !s.checkPermission(req, resourceObject, "modify") {
+			statusCode = 403
+			return
+		}
+		statusCode = s.checkDestination(req, m, destinationPath, isOverwrite)
+	})
+	if statusCode != 0 {
+		res.WriteHeader(statusCode)
+		return
+	}
+
+	// 리소스 이동
+	isOverwritten, err := s.getResourceManager(req).MoveResourceWithLockTokens(path, destinationPath, isOverwrite, username, getSubmittedLockTokens(req))
+	if err != nil {
+		if errors.Is(err, class.ErrAlreadyExists) {
+			res.WriteHeader(412)
+		} else {
+			res.WriteHeader(getErrorStatusCode(err))
+		}
+		return
+	}
+	if isOverwritten {
+		res.WriteHeader(204)
+	} else {
+		res.WriteHeader(201)
+	}
+}
+
+/*
+`Destination` 헤더의 경로 반환
+절대 URL(`http://host/foo/bar`)과 경로(`/foo/bar`) 모두 지원
+*/
+func getDestinationPath(req *http.Request) (string, bool) {
+	destination := req.Header.Get("Destination")
+	if destination == "" {
+		return "", false
+	}
+
+	destinationUrl, err := url.Parse(destination)
+	if err != nil {
+		return "", false
+	}
+	if destinationUrl.Host != "" && destinationUrl.Host != req.Host {
+		return "", false
+	}
+
+	path := destinationUrl.Path
+	if len(path) > 1 && path[len(path)-1] == '/' {
+		path = path[:len(path)-1]
+	}
+	return path, true
+}
+
+/*
+대상 경로에 리소스를 생성하거나 덮어쓸 수 있는지 확인
+대상 경로의 부모 폴더에 "write" 권한, 덮어쓸 리소스가 있으면 "modify" 권한 필요
+View 안에서 호출
+  - @return {int} 실패시 응답 코드, 가능하면 0
+*/
+func (s *ResourceManagerServer) checkDestination(req *http.Request, m *class.ResourceManager, destinationPath string, isOverwrite bool) int {
+	destinationParentResourceObject, statusCode := getParentResourceObject(m, destinationPath)
+	if destinationParentResourceObject == nil {
+		return statusCode
+	}
+	if !s.checkPermission(req, destinationParentResourceObject, "write") {
+		return 403
+	}
+	destinationResourceObject := m.GetResourceObject(destinationPath)
+	if destinationResourceObject != nil && isOverwrite && !s.checkPermission(req, destinationResourceObject, "modify") {
+		return 403
+	}
+	return 0
+}
@@ -0,0 +1,105 @@
+package app
+
+import (
+	"app/class"
+	"app/storage"
+	"net/http"
+	"strings"
+)
+
+// WebDAV 준수 등급 (1: 기본, 2: 잠금)
+const davComplianceClasses = "1, 2"
+
+/*
+OPTIONS: 경로에서 사용할 수 있는 메소드와 WebDAV 준수 등급 반환
+*/
+func (s *ResourceManagerServer) handleOptions(res http.ResponseWriter, req *http.Request) {
+	res.Header().Set("Allow", strings.Join(s.getAllowedMethods(getRequestPath(req)), ", "))
+	res.Header().Set("DAV", davComplianceClasses)
+	res.Header().Set("MS-Author-Via", "DAV")
+	res.WriteHeader(200)
+}
+
+/*
+경로에서 사용할 수 있는 메소드 목록 반환
+*/
+func (s *ResourceManagerServer) getAllowedMethods(path string) []string {
+	isExist := false
+	isFile := false
+	s.resourceManager.View(func(m *class.ResourceManager) {
+		if resourceObject := m.GetResourceObject(path); resourceObject != nil {
+			isExist = true
+			isFile = resourceObject.IsFile()
+		}
+	})
+
+	// 리소스가 없으면 생성 관련 메소드만 사용 가능
+	if !isExist {
+		return []string{"OPTIONS", "PUT", "MKCOL", "LOCK"}
+	}
+
+	methods := []string{"OPTIONS", "GET", "HEAD"}
+	if isFile {
+		methods = append(methods, "PUT", "POST")
+	}
+	methods = append(methods, "PROPFIND", "PROPPATCH")
+	methods = append(methods, "COPY")
+	if path != "/" {
+		methods = append(methods, "DELETE", "MOVE")
+	}
+	methods = append(methods, "LOCK", "UNLOCK")
+	return methods
+}
+
+/*
+/_capabilities: 서버가 지원하는 기능 목록 반환
+*/
+func (s *ResourceManagerServer) handleCapabilities(res http.ResponseWriter, req *http.Request) {
+	if req.Method != "GET" {
+		res.WriteHeader(405)
+		return
+	}
+
+	writeJson(res, 200, s.getCapabilities())
+}
+
+/*
+서버가 지원하는 기능 목록 반환
+*/
+func (s *ResourceManagerServer) getCapabilities() map[string]any {
+	return map[string]any{
+		"webdav": map[string]any{
+			"complianceClasses": []string{"1", "2"},
+			"methods":           []string{"OPTIONS", "GET", "HEAD", "PUT", "POST", "MKCOL", "DELETE", "COPY", "MOVE", "PROPFIND", "PROPPATCH", "LOCK", "UNLOCK"},
+			"deadProperties":    true,
+		},
+		"locking": map[string]any{
+			"enabled": true,
+			"scopes":  []string{"exclusive", "shared"},
+			"depths":  []string{"0", "infinity"},
+		},
+		"versioning": map[string]any{
+			"enabled": true,
+			"policy":  s.resourceManager.GetVersionPolicy().ToMap(),
+		},
+		"quotas": map[string]any{
+			"enabled": true,
+			"scopes":  []string{"user", "group", "directory"},
+		},
+		"snapshots": map[string]any{
+			"enabled":    true,
+			"pathPrefix": snapshotPathPrefix,
+		},
+		"trash": map[string]any{
+			"policy": s.resourceManager.GetTrashPolicy().ToMap(),
+		},
+		"storage": map[string]any{
+			"backend":          storage.GetBackend(s.resourceManager.GetContentStore()),
+			"rangeRequests":    true,
+			"resumableUploads": true,
+		},
+		"auth": map[string]any{
+			"methods": s.getAuthParam().getProviders(),
+		},
+	}
+}
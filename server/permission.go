@@ -0,0 +1,193 @@
+package app
+
+import (
+	"app/constant"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+/*
+/_admin/permissions/{report}: 권한 보고서 조회(GET)
+- effective?path=&user=&group=: 유저와 그룹의 실제 권한과 권한별 확인 과정
+- principals?path=&permission=: 권한을 가진 유저와 그룹 목록
+- access?user=&group=: 유저 또는 그룹이 권한을 가진 리소스 목록
+/_admin/permissions/entries?path=&user=|group=&permission=: 경로의 권한 항목 추가(PUT), 삭제(DELETE)
+/_admin/permissions/{users|groups}/{name}: 트리 전체의 권한 삭제(DELETE), 이름 변경(POST)
+*/
+func (s *ResourceManagerServer) handleAdminPermissions(res http.ResponseWriter, req *http.Request) {
+	if !s.checkAdmin(req) {
+		res.WriteHeader(403)
+		return
+	}
+
+	report, name, hasName := strings.Cut(strings.TrimPrefix(req.URL.Path, "/_admin/permissions/"), "/")
+	if hasName && (report == "users" || report == "groups") {
+		s.handleAdminPrincipalPermissions(res, req, report, name)
+		return
+	}
+	if hasName {
+		res.WriteHeader(404)
+		return
+	}
+	if report == "entries" {
+		s.handleAdminPermissionEntry(res, req)
+		return
+	}
+
+	if req.Method != "GET" {
+		res.Header().Set("Allow", "GET")
+		res.WriteHeader(405)
+		return
+	}
+
+	query := req.URL.Query()
+	path := query.Get("path")
+	username := query.Get("user")
+	groupname := query.Get("group")
+
+	switch report {
+	case "effective":
+		if path == "" || (username == "" && groupname == "") {
+			res.WriteHeader(400)
+			return
+		}
+		effectivePermissions, err := s.getResourceManager(req).GetEffectivePermissions(path, username, groupname)
+		if err != nil {
+			res.WriteHeader(getErrorStatusCode(err))
+			return
+		}
+		writeJson(res, 200, effectivePermissions.ToMap())
+	case "principals":
+		permission := query.Get("permission")
+		if path == "" || permission == "" {
+			res.WriteHeader(400)
+			return
+		}
+		principals, err := s.getResourceManager(req).GetPermissionPrincipals(path, permission)
+		if err != nil {
+			res.WriteHeader(getErrorStatusCode(err))
+			return
+		}
+		principalMaps := []map[string]any{}
+		for _, principal := range principals {
+			principalMaps = append(principalMaps, principal.ToMap())
+		}
+		writeJson(res, 200, map[string]any{
+			"path":       path,
+			"permission": permission,
+			"principals": principalMaps,
+		})
+	case "access":
+		if username == "" && groupname == "" {
+			res.WriteHeader(400)
+			return
+		}
+		accessMaps := []map[string]any{}
+		for _, access := range s.getResourceManager(req).GetPrincipalAccess(username, groupname) {
+			accessMaps = append(accessMaps, access.ToMap())
+		}
+		writeJson(res, 200, map[string]any{
+			"user":      username,
+			"group":     groupname,
+			"resources": accessMaps,
+		})
+	default:
+		res.WriteHeader(404)
+	}
+}
+
+/*
+유저 또는 그룹의 모든 권한 항목을 트리 전체에서 삭제(DELETE)하거나 새 이름으로 옮김(POST)
+*/
+func (s *ResourceManagerServer) handleAdminPrincipalPermissions(res http.ResponseWriter, req *http.Request, scope string, name string) {
+	if name == "" {
+		res.WriteHeader(404)
+		return
+	}
+
+	var paths []string
+	switch req.Method {
+	case ("DELETE"):
+		if scope == "users" {
+			paths = s.getResourceManager(req).RevokeAllForUser(name)
+		} else {
+			paths = s.getResourceManager(req).RevokeAllForGroup(name)
+		}
+	case ("POST"):
+		var param struct {
+			Name string `json:"name"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&param); err != nil || param.Name == "" {
+			res.WriteHeader(400)
+			return
+		}
+		if scope == "users" {
+			paths = s.getResourceManager(req).RenameUser(name, param.Name)
+		} else {
+			paths = s.getResourceManager(req).RenameGroup(name, param.Name)
+		}
+	default:
+		res.Header().Set("Allow", "POST, DELETE")
+		res.WriteHeader(405)
+		return
+	}
+
+	writeJson(res, 200, map[string]any{
+		"resources": paths,
+	})
+}
+
+/*
+경로에 유저 또는 그룹의 권한 항목을 추가(PUT)하거나 삭제(DELETE)
+*/
+func (s *ResourceManagerServer) handleAdminPermissionEntry(res http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+	path := query.Get("path")
+	username := query.Get("user")
+	groupname := query.Get("group")
+	permission := query.Get("permission")
+	if path == "" || (username == "") == (groupname == "") || !slices.Contains(constant.PERMISSIONS[:], permission) {
+		res.WriteHeader(400)
+		return
+	}
+
+	resourceManager := s.getResourceManager(req)
+	modifier := req.Header.Get("User-Name")
+	lockTokens := getSubmittedLockTokens(req)
+
+	switch req.Method {
+	case ("PUT"):
+		var permissions []string
+		var err error
+		if username != "" {
+			permissions, err = resourceManager.AddUserPermissionWithLockTokens(path, username, permission, modifier, lockTokens)
+		} else {
+			permissions, err = resourceManager.AddGroupPermissionWithLockTokens(path, groupname, permission, modifier, lockTokens)
+		}
+		if err != nil {
+			res.WriteHeader(getErrorStatusCode(err))
+			return
+		}
+		writeJson(res, 200, map[string]any{
+			"path":        path,
+			"permissions": permissions,
+		})
+	case ("DELETE"):
+		var err error
+		if username != "" {
+			err = resourceManager.DeleteUserPermissionWithLockTokens(path, username, permission, modifier, lockTokens)
+		} else {
+			err = resourceManager.DeleteGroupPermissionWithLockTokens(path, groupname, permission, modifier, lockTokens)
+		}
+		if err != nil {
+			res.WriteHeader(getErrorStatusCode(err))
+			return
+		}
+		res.WriteHeader(204)
+	default:
+		res.Header().Set("Allow", "PUT, DELETE")
+		res.WriteHeader(405)
+	}
+}
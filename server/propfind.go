@@ -0,0 +1,278 @@
+package app
+
+import (
+	"app/class"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// 서버가 관리하는 속성 중 DAV: 네임스페이스에 없는 속성의 네임스페이스
+const resourceManagerNamespace = "urn:resource-manager"
+
+// allprop 요청시 반환하는 속성 목록
+var davLivePropertyNames = []xml.Name{
+	{Space: "DAV:", Local: "resourcetype"},
+	{Space: "DAV:", Local: "displayname"},
+	{Space: "DAV:", Local: "getcontentlength"},
+	{Space: "DAV:", Local: "getlastmodified"},
+	{Space: "DAV:", Local: "creationdate"},
+	{Space: "DAV:", Local: "creator-displayname"},
+	{Space: "DAV:", Local: "getetag"},
+	{Space: "DAV:", Local: "supportedlock"},
+	{Space: "DAV:", Local: "lockdiscovery"},
+	{Space: resourceManagerNamespace, Local: "lastmodifier"},
+	{Space: resourceManagerNamespace, Local: "size"},
+}
+
+/*
+PROPFIND: 리소스와 하위 리소스의 속성 조회
+`Depth` 헤더에 따라 리소스만(0), 바로 아래 하위 리소스까지(1), 모든 하위 리소스까지(infinity) 조회
+"read" 권한이 없는 하위 리소스는 응답에서 제외
+*/
+func (s *ResourceManagerServer) handlePropfind(res http.ResponseWriter, req *http.Request) {
+	username := req.Header.Get("User-Name")
+	groupname := req.Header.Get("Group-Name")
+	path := getRequestPath(req)
+
+	depth := -1
+	switch req.Header.Get("Depth") {
+	case "0":
+		depth = 0
+	case "1":
+		depth = 1
+	case "", "infinity":
+		depth = -1
+	default:
+		res.WriteHeader(400)
+		return
+	}
+
+	// 요청 본문이 없으면 allprop
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		res.WriteHeader(400)
+		return
+	}
+	propfind := davPropfind{AllProp: &struct{}{}}
+	if len(body) > 0 {
+		propfind = davPropfind{}
+		if err := xml.Unmarshal(body, &propfind); err != nil {
+			res.WriteHeader(400)
+			return
+		}
+	}
+
+	multistatus := davMultistatus{
+		XmlnsD:    "DAV:",
+		Responses: []davResponse{},
+	}
+	var appendResponses func(resourceObject *class.ResourceObject, depth int)
+	appendResponses = func(resourceObject *class.ResourceObject, depth int) {
+		multistatus.Responses = append(multistatus.Responses, s.getPropfindResponse(resourceObject, propfind))
+		if depth == 0 {
+			return
+		}
+
+		for _, child := range resourceObject.GetChildren() {
+			if !child.CheckPermission(username, groupname, "read") {
+				continue
+			}
+			appendResponses(child, depth-1)
+		}
+	}
+	statusCode := 0
+	s.getResourceManager(req).View(func(m *class.ResourceManager) {
+		resourceObject, readStatusCode := s.getReadableResourceObject(req, m, path)
+		if resourceObject == nil {
+			statusCode = readStatusCode
+			return
+		}
+		appendResponses(resourceObject, depth)
+	})
+	if statusCode != 0 {
+		res.WriteHeader(statusCode)
+		return
+	}
+
+	writeXml(res, 207, multistatus)
+}
+
+/*
+리소스 하나에 대한 PROPFIND 응답 생성
+*/
+func (s *ResourceManagerServer) getPropfindResponse(resourceObject *class.ResourceObject, propfind davPropfind) davResponse {
+	response := davResponse{
+		Href:      getDavHref(resourceObject),
+		Propstats: []davPropstat{},
+	}
+
+	// propname: 속성 이름만 반환
+	if propfind.PropName != nil {
+		properties := []davProperty{}
+		for _, name := range davLivePropertyNames {
+			if property, ok := getDavLiveProperty(resourceObject, name); ok {
+				properties = append(properties, davProperty{XMLName: property.XMLName})
+			}
+		}
+		for _, property := range getDavDeadProperties(resourceObject) {
+			properties = append(properties, davProperty{XMLName: property.XMLName})
+		}
+		response.Propstats = append(response.Propstats, davPropstat{
+			Prop:   davProp{Properties: properties},
+			Status: getDavStatus(200),
+		})
+		return response
+	}
+
+	// allprop: 모든 속성 반환
+	if propfind.Prop == nil {
+		properties := []davProperty{}
+		for _, name := range davLivePropertyNames {
+			if property, ok := getDavLiveProperty(resourceObject, name); ok {
+				properties = append(properties, property)
+			}
+		}
+		properties = append(properties, getDavDeadProperties(resourceObject)...)
+		response.Propstats = append(response.Propstats, davPropstat{
+			Prop:   davProp{Properties: properties},
+			Status: getDavStatus(200),
+		})
+		return response
+	}
+
+	// prop: 요청한 속성만 반환, 없는 속성은 404
+	foundProperties := []davProperty{}
+	notFoundProperties := []davProperty{}
+	for _, propName := range propfind.Prop.Names {
+		if property, ok := getDavLiveProperty(resourceObject, propName.XMLName); ok {
+			foundProperties = append(foundProperties, property)
+			continue
+		}
+		if value, ok := resourceObject.GetProperty(propName.XMLName.Space, propName.XMLName.Local); ok {
+			foundProperties = append(foundProperties, davProperty{XMLName: propName.XMLName, InnerXML: value})
+			continue
+		}
+		notFoundProperties = append(notFoundProperties, davProperty{XMLName: getDavPropertyName(propName.XMLName)})
+	}
+	if len(foundProperties) > 0 {
+		response.Propstats = append(response.Propstats, davPropstat{
+			Prop:   davProp{Properties: foundProperties},
+			Status: getDavStatus(200),
+		})
+	}
+	if len(notFoundProperties) > 0 {
+		response.Propstats = append(response.Propstats, davPropstat{
+			Prop:   davProp{Properties: notFoundProperties},
+			Status: getDavStatus(404),
+		})
+	}
+	return response
+}
+
+/*
+서버가 관리하는 속성 값 반환
+리소스에 해당 속성이 없으면 false
+*/
+func getDavLiveProperty(resourceObject *class.ResourceObject, name xml.Name) (davProperty, bool) {
+	if name.Space == resourceManagerNamespace {
+		switch name.Local {
+		case "lastmodifier":
+			return davProperty{XMLName: name, InnerXML: escapeXmlText(resourceObject.GetLastModifier())}, true
+		case "size":
+			return davProperty{XMLName: name, InnerXML: strconv.FormatInt(resourceObject.GetSize(), 10)}, true
+		default:
+			return davProperty{}, false
+		}
+	}
+	if name.Space != "DAV:" {
+		return davProperty{}, false
+	}
+
+	switch name.Local {
+	case "resourcetype":
+		if resourceObject.IsDirectory() {
+			return newDavXmlProperty(name.Local, davCollection{}), true
+		}
+		return newDavTextProperty(name.Local, ""), true
+	case "displayname":
+		return newDavTextProperty(name.Local, resourceObject.GetName()), true
+	case "getcontentlength":
+		if resourceObject.IsDirectory() {
+			return davProperty{}, false
+		}
+		return newDavTextProperty(name.Local, strconv.FormatInt(resourceObject.GetSize(), 10)), true
+	case "getlastmodified":
+		if resourceObject.GetModifiedAt().IsZero() {
+			return davProperty{}, false
+		}
+		return newDavTextProperty(name.Local, resourceObject.GetModifiedAt().UTC().Format(http.TimeFormat)), true
+	case "creationdate":
+		if resourceObject.GetCreatedAt().IsZero() {
+			return davProperty{}, false
+		}
+		return newDavTextProperty(name.Local, resourceObject.GetCreatedAt().UTC().Format(time.RFC3339)), true
+	case "creator-displayname":
+		return newDavTextProperty(name.Local, resourceObject.GetCreator()), true
+	case "getetag":
+		return newDavTextProperty(name.Local, resourceObject.GetETag()), true
+	case "supportedlock":
+		return newDavSupportedLock(), true
+	case "lockdiscovery":
+		return newDavLockDiscovery(resourceObject.GetLocks()), true
+	// RFC 4331, allprop에는 포함하지 않음
+	case "quota-used-bytes":
+		if !resourceObject.IsDirectory() {
+			return davProperty{}, false
+		}
+		return newDavTextProperty(name.Local, strconv.FormatInt(resourceObject.GetQuotaUsage().Bytes, 10)), true
+	case "quota-available-bytes":
+		availableBytes, isLimited := resourceObject.GetQuotaAvailableBytes()
+		if !resourceObject.IsDirectory() || !isLimited {
+			return davProperty{}, false
+		}
+		return newDavTextProperty(name.Local, strconv.FormatInt(availableBytes, 10)), true
+	default:
+		return davProperty{}, false
+	}
+}
+
+/*
+리소스의 사용자 정의 속성 목록 반환
+*/
+func getDavDeadProperties(resourceObject *class.ResourceObject) []davProperty {
+	properties := []davProperty{}
+	for _, property := range resourceObject.GetProperties() {
+		properties = append(properties, davProperty{
+			XMLName:  xml.Name{Space: property.Namespace, Local: property.Name},
+			InnerXML: property.Value,
+		})
+	}
+	return properties
+}
+
+/*
+요청받은 속성 이름을 응답용 이름으로 변환
+DAV: 네임스페이스는 "D:" 접두어 사용
+*/
+func getDavPropertyName(name xml.Name) xml.Name {
+	if name.Space == "DAV:" {
+		return xml.Name{Local: "D:" + name.Local}
+	}
+	return name
+}
+
+/*
+리소스의 href 반환
+폴더는 '/'로 끝남
+*/
+func getDavHref(resourceObject *class.ResourceObject) string {
+	path := resourceObject.GetPath()
+	if resourceObject.IsDirectory() && path != "/" {
+		path += "/"
+	}
+	return (&url.URL{Path: path}).EscapedPath()
+}
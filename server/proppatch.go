@@ -0,0 +1,113 @@
+package app
+
+import (
+	"app/class"
+	"encoding/xml"
+	"io"
+	"net/http"
+)
+
+/*
+PROPPATCH: 리소스의 사용자 정의 속성 설정, 삭제
+모든 속성 변경이 성공하거나 모두 실패함
+*/
+func (s *ResourceManagerServer) handleProppatch(res http.ResponseWriter, req *http.Request) {
+	username := req.Header.Get("User-Name")
+	path := getRequestPath(req)
+
+	statusCode := 0
+	href := ""
+	s.getResourceManager(req).View(func(m *class.ResourceManager) {
+		resourceObject := m.GetResourceObject(path)
+		if resourceObject == nil {
+			statusCode = 404
+			return
+		}
+
+		// 권한 확인
+		if !s.checkPermission(req, resourceObject, "write") {
+			statusCode = 403
+			return
+		}
+		href = getDavHref(resourceObject)
+	})
+	if statusCode != 0 {
+		res.WriteHeader(statusCode)
+		return
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		res.WriteHeader(400)
+		return
+	}
+	var propertyUpdate davPropertyUpdate
+	if err := xml.Unmarshal(body, &propertyUpdate); err != nil {
+		res.WriteHeader(400)
+		return
+	}
+
+	// 요청 본문의 순서대로 속성 변경 목록 생성
+	patches := []class.PropertyPatch{}
+	for _, instruction := range propertyUpdate.Instructions {
+		if instruction.XMLName.Space != "DAV:" || (instruction.XMLName.Local != "set" && instruction.XMLName.Local != "remove") {
+			res.WriteHeader(400)
+			return
+		}
+		for _, value := range instruction.Prop.Values {
+			patches = append(patches, class.PropertyPatch{
+				Namespace: value.XMLName.Space,
+				Name:      value.XMLName.Local,
+				Value:     value.InnerXML,
+				IsRemove:  instruction.XMLName.Local == "remove",
+			})
+		}
+	}
+
+	// DAV: 네임스페이스의 속성은 서버가 관리하므로 변경 불가
+	// 하나라도 실패하면 나머지 속성은 변경하지 않고 424 Failed Dependency
+	forbiddenProperties := []davProperty{}
+	deadProperties := []davProperty{}
+	for _, patch := range patches {
+		name := getDavPropertyName(xml.Name{Space: patch.Namespace, Local: patch.Name})
+		if patch.Namespace == "DAV:" {
+			forbiddenProperties = append(forbiddenProperties, davProperty{XMLName: name})
+		} else {
+			deadProperties = append(deadProperties, davProperty{XMLName: name})
+		}
+	}
+
+	response := davResponse{
+		Href:      href,
+		Propstats: []davPropstat{},
+	}
+	if len(forbiddenProperties) > 0 {
+		response.Propstats = append(response.Propstats, davPropstat{
+			Prop:   davProp{Properties: forbiddenProperties},
+			Status: getDavStatus(403),
+		})
+		if len(deadProperties) > 0 {
+			response.Propstats = append(response.Propstats, davPropstat{
+				Prop:   davProp{Properties: deadProperties},
+				Status: getDavStatus(424),
+			})
+		}
+		writeXml(res, 207, davMultistatus{XmlnsD: "DAV:", Responses: []davResponse{response}})
+		return
+	}
+
+	// 속성 변경
+	err = s.getResourceManager(req).PatchPropertiesWithLockTokens(path, patches, username, getSubmittedLockTokens(req))
+	if err != nil {
+		res.WriteHeader(getErrorStatusCode(err))
+		return
+	}
+
+	if len(deadProperties) > 0 {
+		response.Propstats = append(response.Propstats, davPropstat{
+			Prop:   davProp{Properties: deadProperties},
+			Status: getDavStatus(200),
+		})
+	}
+	writeXml(res, 207, davMultistatus{XmlnsD: "DAV:", Responses: []davResponse{response}})
+}
@@ -0,0 +1,153 @@
+package app
+
+import (
+	"app/class"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+/*
+/_quotas: 요청한 유저, 그룹과 "read" 권한이 있는 폴더의 저장 용량 사용량과 제한 조회(GET)
+*/
+func (s *ResourceManagerServer) handleQuotas(res http.ResponseWriter, req *http.Request) {
+	if req.Method != "GET" {
+		res.Header().Set("Allow", "GET")
+		res.WriteHeader(405)
+		return
+	}
+
+	username := req.Header.Get("User-Name")
+	groupname := req.Header.Get("Group-Name")
+
+	report := map[string]any{
+		"user":        getQuotaReportMap(username, s.getResourceManager(req).GetUserQuota(username), s.getResourceManager(req).GetUserQuotaUsage(username)),
+		"group":       nil,
+		"directories": []map[string]any{},
+	}
+	if groupname != "" {
+		report["group"] = getQuotaReportMap(groupname, s.getResourceManager(req).GetGroupQuota(groupname), s.getResourceManager(req).GetGroupQuotaUsage(groupname))
+	}
+
+	directories := []map[string]any{}
+	for _, directory := range s.getResourceManager(req).GetQuotaDirectories() {
+		if directory.CheckPermission(username, groupname, "read") {
+			directories = append(directories, getDirectoryQuotaReportMap(directory))
+		}
+	}
+	report["directories"] = directories
+
+	writeJson(res, 200, report)
+}
+
+/*
+/_admin/quotas: 모든 저장 용량 제한과 사용량 조회(GET)
+*/
+func (s *ResourceManagerServer) handleAdminQuotas(res http.ResponseWriter, req *http.Request) {
+	if !s.checkAdmin(req) {
+		res.WriteHeader(403)
+		return
+	}
+	if req.Method != "GET" {
+		res.WriteHeader(405)
+		return
+	}
+
+	users := []map[string]any{}
+	for _, username := range s.getResourceManager(req).GetQuotaUsers() {
+		users = append(users, getQuotaReportMap(username, s.getResourceManager(req).GetUserQuota(username), s.getResourceManager(req).GetUserQuotaUsage(username)))
+	}
+	groups := []map[string]any{}
+	for _, groupname := range s.getResourceManager(req).GetQuotaGroups() {
+		groups = append(groups, getQuotaReportMap(groupname, s.getResourceManager(req).GetGroupQuota(groupname), s.getResourceManager(req).GetGroupQuotaUsage(groupname)))
+	}
+	directories := []map[string]any{}
+	for _, directory := range s.getResourceManager(req).GetQuotaDirectories() {
+		directories = append(directories, getDirectoryQuotaReportMap(directory))
+	}
+
+	writeJson(res, 200, map[string]any{
+		"users":       users,
+		"groups":      groups,
+		"directories": directories,
+	})
+}
+
+/*
+/_admin/quotas/{users|groups|directories}/{name}: 저장 용량 제한 변경(PUT), 삭제(DELETE)
+폴더는 이름 대신 경로 사용
+*/
+func (s *ResourceManagerServer) handleAdminQuota(res http.ResponseWriter, req *http.Request) {
+	if !s.checkAdmin(req) {
+		res.WriteHeader(403)
+		return
+	}
+
+	scope, name, _ := strings.Cut(strings.TrimPrefix(req.URL.Path, "/_admin/quotas/"), "/")
+	if scope == "directories" {
+		name = "/" + strings.TrimSuffix(name, "/")
+	}
+	if name == "" {
+		res.WriteHeader(404)
+		return
+	}
+
+	quota := class.ResourceQuota{}
+	switch req.Method {
+	case ("PUT"):
+		var quotaMap map[string]any
+		if err := json.NewDecoder(req.Body).Decode(&quotaMap); err != nil {
+			res.WriteHeader(400)
+			return
+		}
+		quota = class.FromMapResourceQuota(quotaMap)
+	case ("DELETE"):
+	default:
+		res.Header().Set("Allow", "PUT, DELETE")
+		res.WriteHeader(405)
+		return
+	}
+
+	switch scope {
+	case "users":
+		s.getResourceManager(req).SetUserQuota(name, quota)
+	case "groups":
+		s.getResourceManager(req).SetGroupQuota(name, quota)
+	case "directories":
+		if err := s.getResourceManager(req).SetDirectoryQuota(name, quota); err != nil {
+			res.WriteHeader(getErrorStatusCode(err))
+			return
+		}
+	default:
+		res.WriteHeader(404)
+		return
+	}
+
+	if req.Method == "DELETE" {
+		res.WriteHeader(204)
+		return
+	}
+	writeJson(res, 200, quota.ToMap())
+}
+
+/*
+유저 또는 그룹의 사용량과 제한을 응답용 map으로 변환
+*/
+func getQuotaReportMap(name string, quota class.ResourceQuota, usage class.QuotaUsage) map[string]any {
+	return map[string]any{
+		"name":  name,
+		"limit": quota.ToMap(),
+		"usage": usage.ToMap(),
+	}
+}
+
+/*
+폴더의 사용량과 제한을 응답용 map으로 변환
+*/
+func getDirectoryQuotaReportMap(directory *class.ResourceObject) map[string]any {
+	return map[string]any{
+		"path":  directory.GetPath(),
+		"limit": directory.GetQuota().ToMap(),
+		"usage": directory.GetQuotaUsage().ToMap(),
+	}
+}
@@ -0,0 +1,134 @@
+package app
+
+import (
+	"app/audit"
+	"app/class"
+	"app/util"
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// 요청 context에 요청 상태를 저장하는 키
+type requestStateKey struct{}
+
+/*
+처리 중인 요청의 상태
+핸들러에서 확인한 권한을 모아 요청이 끝나면 감사 기록과 지표에 반영
+*/
+type requestState struct {
+	checks []audit.Check
+}
+
+/*
+요청 context에서 요청 상태 반환
+ServeHTTP를 거치지 않은 요청이면 nil
+*/
+func getRequestState(req *http.Request) *requestState {
+	state, _ := req.Context().Value(requestStateKey{}).(*requestState)
+	return state
+}
+
+/*
+응답 코드와 크기를 기록하는 ResponseWriter
+*/
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	if r.status == 0 {
+		r.status = 200
+	}
+	n, err := r.ResponseWriter.Write(data)
+	r.bytes += int64(n)
+	return n, err
+}
+
+func (r *responseRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (r *responseRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+/*
+응답 코드 반환, 아무것도 쓰지 않았으면 200
+*/
+func (r *responseRecorder) getStatus() int {
+	if r.status == 0 {
+		return 200
+	}
+	return r.status
+}
+
+/*
+모든 요청을 인증하여 처리하고 접근 기록, 지표, 감사 기록에 반영
+`X-Request-ID` 헤더가 올바르면 그 값을, 아니면 새로 생성한 값을 요청 ID로 사용하여 응답 헤더와 context에 설정
+*/
+func (s *ResourceManagerServer) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	startedAt := time.Now()
+	requestId := req.Header.Get("X-Request-ID")
+	if !isValidRequestId(requestId) {
+		requestId = util.GenerateRandomString(16)
+	}
+	res.Header().Set("X-Request-ID", requestId)
+
+	state := &requestState{checks: []audit.Check{}}
+	ctx := util.WithRequestId(req.Context(), requestId)
+	ctx = context.WithValue(ctx, requestStateKey{}, state)
+	req = req.WithContext(ctx)
+
+	recorder := &responseRecorder{ResponseWriter: res}
+	s.mux.ServeHTTP(recorder, req)
+
+	status := recorder.getStatus()
+	duration := time.Since(startedAt)
+	s.metrics.observeRequest(req.Method, status, duration, state.checks)
+	s.writeAuditRecord(req, status, state.checks)
+	s.getLogger().LogAttrs(ctx, slog.LevelInfo, "request",
+		slog.String("requestId", requestId),
+		slog.String("method", req.Method),
+		slog.String("path", req.URL.Path),
+		slog.String("user", req.Header.Get("User-Name")),
+		slog.Int("status", status),
+		slog.Int64("bytes", recorder.bytes),
+		slog.Duration("duration", duration),
+	)
+}
+
+/*
+요청으로 받은 요청 ID를 사용할 수 있는지 여부 반환
+128자 이하의 출력 가능한 ASCII 문자만 허용
+*/
+func isValidRequestId(requestId string) bool {
+	if requestId == "" || len(requestId) > 128 {
+		return false
+	}
+	for i := 0; i < len(requestId); i++ {
+		if requestId[i] < 0x21 || requestId[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+/*
+요청의 context를 작업에 전달하는 ResourceManager 반환
+*/
+func (s *ResourceManagerServer) getResourceManager(req *http.Request) *class.ResourceManager {
+	return s.resourceManager.WithContext(req.Context())
+}
@@ -0,0 +1,117 @@
+package app
+
+import (
+	"app/class"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// 검색 결과 개수 기본 제한
+const defaultSearchLimit = 1000
+
+/*
+/_search: 조건에 맞는 리소스 검색(GET)
+요청한 유저가 "read" 권한이 없는 리소스는 하위 리소스와 함께 결과에서 제외
+*/
+func (s *ResourceManagerServer) handleSearch(res http.ResponseWriter, req *http.Request) {
+	if req.Method != "GET" {
+		res.Header().Set("Allow", "GET")
+		res.WriteHeader(405)
+		return
+	}
+
+	username := req.Header.Get("User-Name")
+	groupname := req.Header.Get("Group-Name")
+
+	query, ok := getResourceQuery(req)
+	if !ok {
+		res.WriteHeader(400)
+		return
+	}
+	query.IsVisible = func(resourceObject *class.ResourceObject) bool {
+		return resourceObject.CheckPermission(username, groupname, "read")
+	}
+
+	resourceObjects, err := s.getResourceManager(req).Query(query)
+	if err != nil {
+		res.WriteHeader(getErrorStatusCode(err))
+		return
+	}
+
+	results := []map[string]any{}
+	s.getResourceManager(req).View(func(m *class.ResourceManager) {
+		for _, resourceObject := range resourceObjects {
+			results = append(results, getResourceMetadataMap(resourceObject))
+		}
+	})
+	writeJson(res, 200, map[string]any{
+		"results": results,
+	})
+}
+
+/*
+쿼리 문자열로부터 검색 조건 생성
+값이 올바르지 않으면 false
+*/
+func getResourceQuery(req *http.Request) (class.ResourceQuery, bool) {
+	values := req.URL.Query()
+	query := class.ResourceQuery{
+		Root:            values.Get("root"),
+		PathGlob:        values.Get("path"),
+		NameGlob:        values.Get("name"),
+		Type:            values.Get("type"),
+		PermissionUser:  values.Get("user"),
+		PermissionGroup: values.Get("group"),
+		Permission:      values.Get("permission"),
+		Limit:           defaultSearchLimit,
+	}
+	if query.Type != "" && query.Type != class.RESOURCE_TYPE_FILE && query.Type != class.RESOURCE_TYPE_DIRECTORY {
+		return query, false
+	}
+	// 권한 조건에는 유저 또는 그룹이 필요
+	if query.Permission != "" && query.PermissionUser == "" && query.PermissionGroup == "" {
+		return query, false
+	}
+
+	ok := true
+	parseInt := func(name string, defaultValue int64) int64 {
+		if !values.Has(name) {
+			return defaultValue
+		}
+		value, err := strconv.ParseInt(values.Get(name), 10, 64)
+		if err != nil || value < 0 {
+			ok = false
+		}
+		return value
+	}
+	parseTime := func(name string) time.Time {
+		if !values.Has(name) {
+			return time.Time{}
+		}
+		value, err := time.Parse(time.RFC3339, values.Get(name))
+		if err != nil {
+			ok = false
+		}
+		return value
+	}
+
+	query.MaxDepth = int(parseInt("depth", 0))
+	if limit := parseInt("limit", defaultSearchLimit); limit > 0 && limit < defaultSearchLimit {
+		query.Limit = int(limit)
+	}
+	minSize := parseInt("minSize", -1)
+	maxSize := parseInt("maxSize", -1)
+	modifiedAfter := parseTime("modifiedAfter")
+	modifiedBefore := parseTime("modifiedBefore")
+	creator := values.Get("creator")
+
+	isSizeBetween := class.SizeBetween(minSize, maxSize)
+	isModifiedBetween := class.ModifiedBetween(modifiedAfter, modifiedBefore)
+	query.Predicate = func(resourceObject *class.ResourceObject) bool {
+		return isSizeBetween(resourceObject) &&
+			isModifiedBetween(resourceObject) &&
+			(creator == "" || resourceObject.GetCreator() == creator)
+	}
+	return query, ok
+}
@@ -3,85 +3,473 @@ package app
 import (
 	"app/class"
 	"app/util"
-	"fmt"
+	"app/webhook"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
 	"net/http"
 	"strconv"
+	"strings"
+	"sync"
 )
 
 type ResourceManagerServer struct {
 	resourceManager *class.ResourceManager
 	mux             *http.ServeMux
+	uploadSessions  map[string]*uploadSession
+	uploadMutex     sync.Mutex
+	metrics         *metricsRegistry
+	logger          *slog.Logger
+	webhooks        *webhook.Dispatcher
+	// 실행 중 변경할 수 있는 설정
+	settingsMutex     sync.RWMutex
+	authParam         AuthParam
+	lockTimeoutPolicy LockTimeoutPolicy
+	// Start, Shutdown 상태
+	lifecycleMutex sync.Mutex
+	httpServer     *http.Server
+	listener       net.Listener
+	shutdownHooks  []func(ctx context.Context) error
+	isShutdown     bool
+	serveErr       error
+	shutdownErr    error
+	// 종료를 시작하면 닫힘, 이벤트 스트림처럼 끝나지 않는 요청을 끝내는 데 사용
+	closing chan struct{}
+	// 종료가 끝나면 닫힘
+	stopped chan struct{}
 }
 
 /*
 해당 포트에서 서버 시작
 */
 func (s *ResourceManagerServer) Listen(port int) {
+	err := s.ListenAndServe(":" + strconv.Itoa(port))
+	if err != nil {
+		s.getLogger().Error("서버 시작에 오류가 발생했습니다", slog.Any("error", err))
+		return
+	}
+}
+
+/*
+해당 주소에서 서버를 시작하고 종료될 때까지 대기
+  - @param {string} address "{host}:{port}" 형식, host가 비어있으면 모든 주소
+*/
+func (s *ResourceManagerServer) ListenAndServe(address string) error {
+	if err := s.Start(context.Background(), ListenParam{Address: address}); err != nil {
+		return err
+	}
+	return s.Wait()
+}
+
+/*
+해당 주소에서 TLS 서버를 시작하고 종료될 때까지 대기
+  - @param {string} certFile 인증서 파일 경로
+  - @param {string} keyFile 개인 키 파일 경로
+*/
+func (s *ResourceManagerServer) ListenAndServeTLS(address string, certFile string, keyFile string) error {
+	if err := s.Start(context.Background(), ListenParam{Address: address, CertFile: certFile, KeyFile: keyFile}); err != nil {
+		return err
+	}
+	return s.Wait()
+}
+
+/*
+경로별 핸들러 등록
+*/
+func (s *ResourceManagerServer) registerHandlers() {
+	s.mux.HandleFunc("/_admin/locks", s.handleAdminLocks)
+	s.mux.HandleFunc("/_admin/versions", s.handleAdminVersions)
+	s.mux.HandleFunc("/_admin/trash", s.handleAdminTrash)
+	s.mux.HandleFunc("/_admin/quotas", s.handleAdminQuotas)
+	s.mux.HandleFunc("/_admin/quotas/", s.handleAdminQuota)
+	s.mux.HandleFunc("/_admin/permissions/", s.handleAdminPermissions)
+	s.mux.HandleFunc("/_admin/webhooks", s.handleAdminWebhooks)
+	s.mux.HandleFunc("/_admin/webhooks/", s.handleAdminWebhook)
+	s.mux.HandleFunc("/_admin/export", s.handleAdminExport)
+	s.mux.HandleFunc("/_admin/import", s.handleAdminImport)
+	s.mux.HandleFunc("/_quotas", s.handleQuotas)
+	s.mux.HandleFunc("/_search", s.handleSearch)
+	s.mux.HandleFunc("/_events", s.handleEvents)
+	s.mux.HandleFunc("/_trash", s.handleTrash)
+	s.mux.HandleFunc("/_trash/", s.handleTrashItem)
+	s.mux.HandleFunc("/_snapshots", s.handleSnapshots)
+	s.mux.HandleFunc("/_snapshots/", s.handleSnapshot)
+	s.mux.HandleFunc("/_capabilities", s.handleCapabilities)
+	s.mux.HandleFunc("/metrics", s.handleMetrics)
+	s.mux.HandleFunc("/_uploads", s.handleUploads)
+	s.mux.HandleFunc("/_uploads/", s.handleUploadSession)
 	s.mux.HandleFunc("/", func(res http.ResponseWriter, req *http.Request) {
-		username := req.Header.Get("User-Name")
-		groupname := req.Header.Get("Group-Name")
+		// 스냅샷은 읽기 전용
+		if isSnapshotPath(getRequestPath(req)) {
+			s.handleSnapshotPath(res, req)
+			return
+		}
 
 		switch req.Method {
+		case ("OPTIONS"):
+			s.handleOptions(res, req)
+		case ("GET"), ("HEAD"):
+			s.handleGet(res, req)
 		case ("PUT"):
-			{
-				// 이미 해당 경로에 리소스가 있는지 확인
-				if s.resourceManager.GetResourceObject(req.URL.Path) != nil {
-					res.WriteHeader(409)
-					return
-				}
+			s.handlePut(res, req)
+		case ("POST"):
+			s.handlePost(res, req)
+		case ("DELETE"):
+			s.handleDelete(res, req)
+		case ("MKCOL"):
+			s.handleMkcol(res, req)
+		case ("MOVE"):
+			s.handleMove(res, req)
+		case ("COPY"):
+			s.handleCopy(res, req)
+		case ("PROPFIND"):
+			s.handlePropfind(res, req)
+		case ("PROPPATCH"):
+			s.handleProppatch(res, req)
+		case ("LOCK"):
+			s.handleLock(res, req)
+		case ("UNLOCK"):
+			s.handleUnlock(res, req)
+		default:
+			res.Header().Set("Allow", strings.Join(s.getAllowedMethods(getRequestPath(req)), ", "))
+			res.WriteHeader(405)
+		}
+	})
+}
 
-				// 부모 리소스 객체 존재 확인
-				var parentResourceObject *class.ResourceObject
-				for {
-					parentPath, err := util.GetParentDirectory(req.URL.Path)
-					if err != nil {
-						res.WriteHeader(400)
-						return
-					}
-					parentResourceObject = s.resourceManager.GetResourceObject(parentPath)
-					if parentResourceObject != nil || parentPath == "/" {
-						break
-					}
-				}
-				if parentResourceObject == nil {
-					res.WriteHeader(400)
-					return
-				}
+/*
+PUT: 경로에 파일을 생성하거나 파일 내용을 덮어씀
+상위 폴더는 생성하지 않으며, 폴더는 MKCOL로 생성
+`If-None-Match: *`이면 파일이 없을 때만 생성
+*/
+func (s *ResourceManagerServer) handlePut(res http.ResponseWriter, req *http.Request) {
+	path := getRequestPath(req)
 
-				// 권한 확인
-				hasWritePermission :=
-					parentResourceObject.CheckGroupPermission(groupname, "all") ||
-						parentResourceObject.CheckGroupPermission(groupname, "write") ||
-						parentResourceObject.CheckUserPermission(username, "all") ||
-						parentResourceObject.CheckUserPermission(username, "write")
-				if !hasWritePermission {
-					res.WriteHeader(403)
-					return
-				}
+	// 폴더 생성은 MKCOL 사용
+	if req.Header.Get("Is-Directory") == "true" {
+		res.WriteHeader(400)
+		return
+	}
 
-				// 헤더에서 리소스가 디렉토리인지 아닌지 여부
-				isDirectory := false
-				if req.Header.Get("Is-Directory") == "true" {
-					isDirectory = true
-				}
+	// 크기를 알면 내용을 받기 전에 용량 확인
+	if req.ContentLength > 0 {
+		err := s.getResourceManager(req).CheckContentQuota(path, req.Header.Get("User-Name"), req.Header.Get("Group-Name"), req.ContentLength)
+		if err != nil {
+			res.WriteHeader(getErrorStatusCode(err))
+			return
+		}
+	}
+
+	s.putContent(res, req, path, req.Body)
+}
+
+/*
+POST: `restore` 쿼리가 있으면 파일의 이전 버전 복원
+*/
+func (s *ResourceManagerServer) handlePost(res http.ResponseWriter, req *http.Request) {
+	if req.URL.Query().Has("restore") {
+		s.restoreVersion(res, req)
+		return
+	}
+
+	res.Header().Set("Allow", strings.Join(s.getAllowedMethods(getRequestPath(req)), ", "))
+	res.WriteHeader(405)
+}
+
+/*
+경로에 파일을 생성하거나 기존 파일의 내용을 덮어씀
+  - @return {bool} 저장 성공 여부, 실패하면 응답 코드를 이미 작성함
+*/
+func (s *ResourceManagerServer) putContent(res http.ResponseWriter, req *http.Request, path string, content io.Reader) bool {
+	username := req.Header.Get("User-Name")
+	groupname := req.Header.Get("Group-Name")
+
+	statusCode := 0
+	s.getResourceManager(req).View(func(m *class.ResourceManager) {
+		statusCode = checkPreconditions(req, m.GetResourceObject(path))
+		if statusCode == 0 {
+			statusCode = s.checkWritableFile(req, m, path)
+		}
+	})
+	if statusCode != 0 {
+		res.WriteHeader(statusCode)
+		return false
+	}
+
+	// 내용을 모두 받은 후 파일 생성과 내용 저장을 한 번에 처리
+	isCreated, err := s.getResourceManager(req).PutContentWithLockTokens(path, content, username, groupname, getSubmittedLockTokens(req))
+	if err != nil {
+		res.WriteHeader(getErrorStatusCode(err))
+		return false
+	}
+
+	s.getResourceManager(req).View(func(m *class.ResourceManager) {
+		if resourceObject := m.GetResourceObject(path); resourceObject != nil {
+			res.Header().Set("ETag", resourceObject.GetETag())
+		}
+	})
+	if isCreated {
+		res.WriteHeader(201)
+	} else {
+		res.WriteHeader(204)
+	}
+	return true
+}
+
+/*
+경로에 파일을 생성하거나 덮어쓸 수 있는지 확인
+리소스가 없으면 부모 폴더의 "write" 권한, 있으면 파일의 "write" 권한 필요
+View 안에서 호출
+  - @return {int} 실패 시 응답 코드, 가능하면 0
+*/
+func (s *ResourceManagerServer) checkWritableFile(req *http.Request, m *class.ResourceManager, path string) int {
+	resourceObject := m.GetResourceObject(path)
+	if resourceObject == nil {
+		// 부모 리소스 객체 존재 확인
+		parentResourceObject, statusCode := getParentResourceObject(m, path)
+		if parentResourceObject == nil {
+			return statusCode
+		}
+
+		// 권한 확인
+		if !s.checkPermission(req, parentResourceObject, "write") {
+			return 403
+		}
+		return 0
+	}
+
+	if resourceObject.IsDirectory() {
+		return 405
+	}
+
+	// 권한 확인
+	if !s.checkPermission(req, resourceObject, "write") {
+		return 403
+	}
+	return 0
+}
+
+/*
+MKCOL: 경로에 폴더 생성
+*/
+func (s *ResourceManagerServer) handleMkcol(res http.ResponseWriter, req *http.Request) {
+	username := req.Header.Get("User-Name")
+	groupname := req.Header.Get("Group-Name")
+	path := getRequestPath(req)
+
+	// 요청 본문은 지원하지 않음
+	if req.ContentLength > 0 {
+		res.WriteHeader(415)
+		return
+	}
+
+	statusCode := 0
+	s.getResourceManager(req).View(func(m *class.ResourceManager) {
+		// 이미 해당 경로에 리소스가 있으면 405
+		if m.GetResourceObject(path) != nil {
+			statusCode = 405
+			return
+		}
+
+		// 부모 리소스 객체 존재 확인
+		parentResourceObject, parentStatusCode := getParentResourceObject(m, path)
+		if parentResourceObject == nil {
+			statusCode = parentStatusCode
+			return
+		}
+
+		// 권한 확인
+		if !s.checkPermission(req, parentResourceObject, "write") {
+			statusCode = 403
+		}
+	})
+	if statusCode != 0 {
+		res.WriteHeader(statusCode)
+		return
+	}
+
+	// 폴더 생성
+	_, err := s.getResourceManager(req).CreateResourceWithLockTokens(path, true, true, username, groupname, getSubmittedLockTokens(req))
+	if err != nil {
+		res.WriteHeader(getErrorStatusCode(err))
+		return
+	}
+	res.WriteHeader(201)
+}
+
+/*
+경로의 부모 폴더 반환
+View 안에서 호출
+  - @return {*class.ResourceObject} 부모 폴더, 실패시 nil
+  - @return {int} 실패시 응답 코드 (경로가 올바르지 않으면 400, 부모 폴더가 없거나 파일이면 409)
+*/
+func getParentResourceObject(m *class.ResourceManager, path string) (*class.ResourceObject, int) {
+	if !util.IsValidPath(path) || path == "/" {
+		return nil, 400
+	}
+
+	parentPath, err := util.GetParentDirectory(path)
+	if err != nil {
+		return nil, 400
+	}
+	parentResourceObject := m.GetResourceObject(parentPath)
+	if parentResourceObject == nil || !parentResourceObject.IsDirectory() {
+		return nil, 409
+	}
+	return parentResourceObject, 0
+}
+
+/*
+DELETE: 경로의 리소스 삭제
+*/
+func (s *ResourceManagerServer) handleDelete(res http.ResponseWriter, req *http.Request) {
+	username := req.Header.Get("User-Name")
+	path := getRequestPath(req)
+
+	statusCode := 0
+	s.getResourceManager(req).View(func(m *class.ResourceManager) {
+		resourceObject := m.GetResourceObject(path)
+		if resourceObject == nil {
+			statusCode = 404
+			return
+		}
+		if statusCode = checkPreconditions(req, resourceObject); statusCode != 0 {
+			return
+		}
+
+		// 권한 확인
+		if !s.checkPermission(req, resourceObject, "modify") {
+			statusCode = 403
+		}
+	})
+	if statusCode != 0 {
+		res.WriteHeader(statusCode)
+		return
+	}
+
+	// 리소스 삭제
+	err := s.getResourceManager(req).DeleteResourceWithLockTokens(path, username, getSubmittedLockTokens(req))
+	if err != nil {
+		res.WriteHeader(getErrorStatusCode(err))
+		return
+	}
+	res.WriteHeader(204)
+}
+
+/*
+요청 경로 반환
+폴더 경로 끝의 '/'는 제거 (예시: `/foo/` -> `/foo`)
+*/
+func getRequestPath(req *http.Request) string {
+	path := req.URL.Path
+	if len(path) > 1 && strings.HasSuffix(path, "/") {
+		path = path[:len(path)-1]
+	}
+	return path
+}
 
-				// 리소스 생성
-				success, _ := s.resourceManager.CreateResource(req.URL.Path, isDirectory)
-				if success {
-					res.WriteHeader(201)
-				} else {
-					res.WriteHeader(500)
+/*
+요청의 `If`, `Lock-Token` 헤더에서 제출된 잠금 토큰 목록 반환
+`If: (<token1>) (<token2>)`, `If: <http://host/path> (<token>)` 형식 지원
+*/
+func getSubmittedLockTokens(req *http.Request) []string {
+	lockTokens := []string{}
+
+	for _, ifHeader := range req.Header.Values("If") {
+		depth := 0
+		for i := 0; i < len(ifHeader); i++ {
+			switch ifHeader[i] {
+			case '(':
+				depth++
+			case ')':
+				depth--
+			case '<':
+				end := strings.IndexByte(ifHeader[i:], '>')
+				if end < 0 {
+					i = len(ifHeader)
+					break
 				}
-				return
+				if depth > 0 { // 괄호 밖의 <...>는 리소스 태그
+					lockTokens = append(lockTokens, trimLockToken(ifHeader[i+1:i+end]))
+				}
+				i += end
 			}
 		}
-	})
+	}
+
+	lockTokenHeader := req.Header.Get("Lock-Token")
+	if lockTokenHeader != "" {
+		lockTokens = append(lockTokens, trimLockToken(strings.Trim(lockTokenHeader, "<>")))
+	}
+
+	return lockTokens
+}
+
+/*
+잠금 토큰의 `opaquelocktoken:` 접두어 제거
+*/
+func trimLockToken(lockToken string) string {
+	return strings.TrimPrefix(strings.TrimSpace(lockToken), "opaquelocktoken:")
+}
 
-	err := http.ListenAndServe(":"+strconv.Itoa(port), s.mux)
+/*
+JSON 응답 작성
+*/
+func writeJson(res http.ResponseWriter, statusCode int, body any) {
+	data, err := json.Marshal(body)
 	if err != nil {
-		fmt.Println("서버 시작에 오류가 발생했습니다: ", err)
+		res.WriteHeader(500)
 		return
 	}
+
+	res.Header().Set("Content-Type", "application/json; charset=utf-8")
+	res.WriteHeader(statusCode)
+	res.Write(data)
+}
+
+/*
+ResourceManager가 반환한 에러에 해당하는 응답 코드 반환
+*/
+func getErrorStatusCode(err error) int {
+	switch {
+	case errors.Is(err, class.ErrInvalidPath):
+		return 400
+	case errors.Is(err, class.ErrNotFound):
+		return 404
+	case errors.Is(err, class.ErrAlreadyExists):
+		return 409
+	case errors.Is(err, class.ErrParentNotFound):
+		return 409
+	case errors.Is(err, class.ErrNotDirectory):
+		return 409
+	case errors.Is(err, class.ErrNotFile):
+		return 405
+	case errors.Is(err, class.ErrLocked):
+		return 423
+	case errors.Is(err, class.ErrVersionNotFound):
+		return 404
+	case errors.Is(err, class.ErrTrashItemNotFound):
+		return 404
+	case errors.Is(err, class.ErrSnapshotNotFound):
+		return 404
+	case errors.Is(err, class.ErrPermissionDenied):
+		return 403
+	case errors.Is(err, class.ErrQuotaExceeded):
+		return 507
+	case errors.Is(err, class.ErrInvalidData):
+		return 400
+	case errors.Is(err, util.ErrInvalidGlob):
+		return 400
+	case errors.Is(err, webhook.ErrInvalidUrl), errors.Is(err, webhook.ErrInvalidPathPrefix), errors.Is(err, webhook.ErrInvalidEventType):
+		return 400
+	case errors.Is(err, webhook.ErrSubscriptionNotFound), errors.Is(err, webhook.ErrDeliveryNotFound):
+		return 404
+	default:
+		return 500
+	}
 }
 
 /*
@@ -90,8 +478,15 @@ ResourceManagerServer 시작
 func NewServer(resourceManager *class.ResourceManager) *ResourceManagerServer {
 	mux := http.NewServeMux()
 
-	return &ResourceManagerServer{
+	s := &ResourceManagerServer{
 		resourceManager: resourceManager,
 		mux:             mux,
+		uploadSessions:  map[string]*uploadSession{},
+		metrics:         newMetricsRegistry(),
+		webhooks:        webhook.NewDispatcher(resourceManager.GetEventBus(), webhook.DispatcherParam{}),
+		closing:         make(chan struct{}),
+		stopped:         make(chan struct{}),
 	}
+	s.registerHandlers()
+	return s
 }
@@ -0,0 +1,255 @@
+package servertest
+
+import (
+	"app/class"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+/*
+alice에게 모든 권한을 준 트리로 테스트 서버 시작
+/docs/a.txt, /docs/sub/b.txt 파일이 있음
+*/
+func startTestServer(t *testing.T) (*Server, *class.ResourceManager) {
+	t.Helper()
+
+	m := class.NewResourceManager()
+	m.AddUserPermission("/", "alice", class.PERMISSION_ALL)
+	s := Start(t, m)
+	for _, step := range []struct {
+		method string
+		path   string
+		body   string
+	}{
+		{"MKCOL", "/docs", ""},
+		{"MKCOL", "/docs/sub", ""},
+		{"PUT", "/docs/a.txt", "hello world"},
+		{"PUT", "/docs/sub/b.txt", "b"},
+	} {
+		res := request(t, s, step.method, step.path, nil, step.body)
+		if res.StatusCode != 201 {
+			t.Fatalf("%s %s = %d, want 201", step.method, step.path, res.StatusCode)
+		}
+	}
+	return s, m
+}
+
+/*
+alice로 요청을 보내고 응답 본문을 읽은 응답 반환
+*/
+func request(t *testing.T, s *Server, method string, path string, header map[string]string, body string) *http.Response {
+	t.Helper()
+
+	req, err := http.NewRequest(method, s.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("User-Name", "alice")
+	for key, value := range header {
+		req.Header.Set(key, value)
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body = io.NopCloser(strings.NewReader(string(data)))
+	return res
+}
+
+func readBody(t *testing.T, res *http.Response) string {
+	t.Helper()
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestPropfindDepth(t *testing.T) {
+	s, _ := startTestServer(t)
+
+	tests := []struct {
+		depth     string
+		wantHrefs []string
+	}{
+		{"0", []string{"/docs/"}},
+		{"1", []string{"/docs/", "/docs/a.txt", "/docs/sub/"}},
+		{"infinity", []string{"/docs/", "/docs/a.txt", "/docs/sub/", "/docs/sub/b.txt"}},
+	}
+	for _, test := range tests {
+		t.Run(test.depth, func(t *testing.T) {
+			res := request(t, s, "PROPFIND", "/docs", map[string]string{"Depth": test.depth}, "")
+			if res.StatusCode != 207 {
+				t.Fatalf("status = %d, want 207", res.StatusCode)
+			}
+
+			var multistatus struct {
+				Responses []struct {
+					Href string `xml:"href"`
+				} `xml:"response"`
+			}
+			if err := xml.Unmarshal([]byte(readBody(t, res)), &multistatus); err != nil {
+				t.Fatal(err)
+			}
+			hrefs := []string{}
+			for _, response := range multistatus.Responses {
+				hrefs = append(hrefs, response.Href)
+			}
+			if strings.Join(hrefs, ",") != strings.Join(test.wantHrefs, ",") {
+				t.Errorf("href = %v, want %v", hrefs, test.wantHrefs)
+			}
+		})
+	}
+
+	if res := request(t, s, "PROPFIND", "/docs", map[string]string{"Depth": "2"}, ""); res.StatusCode != 400 {
+		t.Errorf("Depth: 2 status = %d, want 400", res.StatusCode)
+	}
+}
+
+func TestConditionalRequests(t *testing.T) {
+	s, _ := startTestServer(t)
+	etag := request(t, s, "HEAD", "/docs/a.txt", nil, "").Header.Get("ETag")
+	if etag == "" {
+		t.Fatal("ETag 헤더가 없음")
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		header     map[string]string
+		wantStatus int
+	}{
+		{"같은 ETag면 304", "GET", "/docs/a.txt", map[string]string{"If-None-Match": etag}, 304},
+		{"다른 ETag면 200", "GET", "/docs/a.txt", map[string]string{"If-None-Match": `"other"`}, 200},
+		{"If-Match가 다르면 덮어쓰지 않음", "PUT", "/docs/a.txt", map[string]string{"If-Match": `"other"`}, 412},
+		{"If-None-Match: *이면 덮어쓰지 않음", "PUT", "/docs/a.txt", map[string]string{"If-None-Match": "*"}, 412},
+		{"If-None-Match: *이면 새로 생성", "PUT", "/docs/new.txt", map[string]string{"If-None-Match": "*"}, 201},
+		{"If-Match가 다르면 삭제하지 않음", "DELETE", "/docs/a.txt", map[string]string{"If-Match": `"other"`}, 412},
+		{"If-Match가 같으면 덮어씀", "PUT", "/docs/a.txt", map[string]string{"If-Match": etag}, 204},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res := request(t, s, test.method, test.path, test.header, "changed")
+			if res.StatusCode != test.wantStatus {
+				t.Errorf("status = %d, want %d", res.StatusCode, test.wantStatus)
+			}
+		})
+	}
+}
+
+func TestRangeRequests(t *testing.T) {
+	s, _ := startTestServer(t)
+	etag := request(t, s, "HEAD", "/docs/a.txt", nil, "").Header.Get("ETag")
+
+	tests := []struct {
+		name       string
+		header     map[string]string
+		wantStatus int
+		wantBody   string
+	}{
+		{"앞부분", map[string]string{"Range": "bytes=0-4"}, 206, "hello"},
+		{"뒷부분", map[string]string{"Range": "bytes=-5"}, 206, "world"},
+		{"같은 If-Range", map[string]string{"Range": "bytes=6-", "If-Range": etag}, 206, "world"},
+		{"다른 If-Range면 전체", map[string]string{"Range": "bytes=6-", "If-Range": `"other"`}, 200, "hello world"},
+		{"범위 밖", map[string]string{"Range": "bytes=100-"}, 416, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res := request(t, s, "GET", "/docs/a.txt", test.header, "")
+			if res.StatusCode != test.wantStatus {
+				t.Fatalf("status = %d, want %d", res.StatusCode, test.wantStatus)
+			}
+			if body := readBody(t, res); test.wantBody != "" && body != test.wantBody {
+				t.Errorf("body = %q, want %q", body, test.wantBody)
+			}
+		})
+	}
+}
+
+func TestUploadSession(t *testing.T) {
+	s, _ := startTestServer(t)
+
+	res := request(t, s, "POST", "/_uploads", nil, `{"path": "/docs/up.txt", "size": 11}`)
+	if res.StatusCode != 201 {
+		t.Fatalf("세션 생성 status = %d, want 201", res.StatusCode)
+	}
+	var session struct {
+		Id string `json:"id"`
+	}
+	if err := json.Unmarshal([]byte(readBody(t, res)), &session); err != nil {
+		t.Fatal(err)
+	}
+	sessionPath := "/_uploads/" + session.Id
+
+	steps := []struct {
+		name         string
+		method       string
+		contentRange string
+		body         string
+		wantStatus   int
+	}{
+		{"다 받기 전에는 커밋 불가", "POST", "", "", 409},
+		{"첫 범위", "PUT", "bytes 0-4/11", "hello", 204},
+		{"받은 크기에서 시작하지 않는 범위", "PUT", "bytes 8-10/11", "rld", 409},
+		{"나머지 범위", "PUT", "bytes 5-10/11", " world", 204},
+		{"커밋", "POST", "", "", 201},
+	}
+	for _, step := range steps {
+		header := map[string]string{}
+		if step.contentRange != "" {
+			header["Content-Range"] = step.contentRange
+		}
+		if res := request(t, s, step.method, sessionPath, header, step.body); res.StatusCode != step.wantStatus {
+			t.Fatalf("%s: status = %d, want %d", step.name, res.StatusCode, step.wantStatus)
+		}
+	}
+
+	if body := readBody(t, request(t, s, "GET", "/docs/up.txt", nil, "")); body != "hello world" {
+		t.Errorf("업로드한 내용 = %q, want %q", body, "hello world")
+	}
+	if res := request(t, s, "GET", sessionPath, nil, ""); res.StatusCode != 404 {
+		t.Errorf("커밋한 세션 status = %d, want 404", res.StatusCode)
+	}
+}
+
+func TestQuotaExceeded(t *testing.T) {
+	s, m := startTestServer(t)
+	// 지금까지 alice가 생성한 파일은 12 byte
+	m.SetUserQuota("alice", class.ResourceQuota{MaxBytes: 20})
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+	}{
+		{"제한 안에서 생성", "PUT", "/docs/c.txt", "12345678", 201},
+		{"제한을 넘는 생성", "PUT", "/docs/d.txt", "x", 507},
+		{"제한을 넘는 덮어쓰기", "PUT", "/docs/sub/b.txt", "bb", 507},
+		{"크기를 줄이는 덮어쓰기", "PUT", "/docs/a.txt", "hello", 204},
+		{"제한을 넘는 업로드 세션", "POST", "/_uploads", `{"path": "/docs/e.txt", "size": 10}`, 507},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if res := request(t, s, test.method, test.path, nil, test.body); res.StatusCode != test.wantStatus {
+				t.Errorf("status = %d, want %d", res.StatusCode, test.wantStatus)
+			}
+		})
+	}
+	if usage := m.GetUserQuotaUsage("alice"); usage.Bytes != 14 {
+		t.Errorf("사용량 = %d byte, want 14", usage.Bytes)
+	}
+}
@@ -0,0 +1,45 @@
+package servertest
+
+import (
+	"app/class"
+	server "app/server"
+	"context"
+	"testing"
+	"time"
+)
+
+/*
+사용하지 않는 포트에서 실행 중인 테스트 서버
+*/
+type Server struct {
+	*server.ResourceManagerServer
+	// 서버 주소 (예시: http://127.0.0.1:54321)
+	URL string
+}
+
+/*
+127.0.0.1의 사용하지 않는 포트에서 서버 시작
+테스트가 끝나면 서버를 종료하며, 시작이나 종료에 실패하면 테스트 실패
+  - @param {...func(*server.ResourceManagerServer)} configure 시작 전에 서버를 설정하는 함수 (예시: SetAuthParam)
+*/
+func Start(t testing.TB, resourceManager *class.ResourceManager, configure ...func(s *server.ResourceManagerServer)) *Server {
+	t.Helper()
+	s := server.NewServer(resourceManager)
+	for _, fn := range configure {
+		fn(s)
+	}
+	if err := s.Start(context.Background(), server.ListenParam{Address: "127.0.0.1:0"}); err != nil {
+		t.Fatalf("테스트 서버를 시작하지 못했습니다: %v", err)
+	}
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := s.Shutdown(ctx); err != nil {
+			t.Errorf("테스트 서버를 종료하지 못했습니다: %v", err)
+		}
+	})
+	return &Server{
+		ResourceManagerServer: s,
+		URL:                   "http://" + s.Addr().String(),
+	}
+}
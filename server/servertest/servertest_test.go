@@ -0,0 +1,112 @@
+package servertest
+
+import (
+	"app/class"
+	server "app/server"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestShutdownWaitsForInFlightRequest(t *testing.T) {
+	m := class.NewResourceManager()
+	m.AddUserPermission("/", "alice", class.PERMISSION_ALL)
+	s := Start(t, m)
+
+	// 종료 작업은 처리 중인 요청이 반영된 트리를 봐야 함
+	hookResult := make(chan bool, 1)
+	s.RegisterOnShutdown(func(ctx context.Context) error {
+		isWritten := false
+		m.View(func(m *class.ResourceManager) {
+			resource := m.GetResourceObject("/a.txt")
+			isWritten = resource != nil && resource.GetSize() == int64(len("hello world"))
+		})
+		hookResult <- isWritten
+		return nil
+	})
+
+	// 본문을 나누어 보내 요청이 처리 중인 상태를 유지
+	bodyReader, bodyWriter := io.Pipe()
+	req, err := http.NewRequest("PUT", s.URL+"/a.txt", bodyReader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("User-Name", "alice")
+	req.ContentLength = int64(len("hello world"))
+	type response struct {
+		statusCode int
+		err        error
+	}
+	responseResult := make(chan response, 1)
+	go func() {
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			responseResult <- response{err: err}
+			return
+		}
+		res.Body.Close()
+		responseResult <- response{statusCode: res.StatusCode}
+	}()
+	if _, err := bodyWriter.Write([]byte("hello")); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	shutdownResult := make(chan error, 1)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		shutdownResult <- s.Shutdown(ctx)
+	}()
+
+	// 요청이 끝나기 전에는 종료하지 않고 종료 작업도 실행하지 않음
+	select {
+	case err := <-shutdownResult:
+		t.Fatalf("처리 중인 요청이 있는데 종료됨: %v", err)
+	case <-hookResult:
+		t.Fatal("처리 중인 요청이 있는데 종료 작업이 실행됨")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	if _, err := bodyWriter.Write([]byte(" world")); err != nil {
+		t.Fatal(err)
+	}
+	bodyWriter.Close()
+
+	result := <-responseResult
+	if result.err != nil || result.statusCode != 201 {
+		t.Fatalf("응답 = %d, %v, want 201", result.statusCode, result.err)
+	}
+	if err := <-shutdownResult; err != nil {
+		t.Fatalf("Shutdown error = %v", err)
+	}
+	select {
+	case isWritten := <-hookResult:
+		if !isWritten {
+			t.Error("종료 작업이 처리 중이던 요청의 결과를 보지 못함")
+		}
+	default:
+		t.Error("종료 작업이 실행되지 않음")
+	}
+}
+
+func TestStartAfterShutdown(t *testing.T) {
+	s := Start(t, class.NewResourceManager())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := s.Shutdown(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Start(context.Background(), server.ListenParam{Address: "127.0.0.1:0"}); !errors.Is(err, server.ErrServerClosed) {
+		t.Errorf("Start error = %v, want %v", err, server.ErrServerClosed)
+	}
+	// 다시 종료해도 같은 결과 반환
+	if err := s.Shutdown(ctx); err != nil {
+		t.Errorf("두 번째 Shutdown error = %v", err)
+	}
+}
@@ -0,0 +1,327 @@
+package app
+
+import (
+	"app/class"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// 스냅샷을 읽기 전용으로 조회하는 경로
+const snapshotPathPrefix = "/.snapshots"
+
+/*
+/_snapshots: 스냅샷 목록 조회(GET), 생성(POST)
+생성은 관리자만 가능
+*/
+func (s *ResourceManagerServer) handleSnapshots(res http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case ("GET"):
+		snapshots := []map[string]any{}
+		for _, snapshot := range s.getResourceManager(req).GetSnapshots() {
+			snapshots = append(snapshots, getSnapshotMap(snapshot))
+		}
+		writeJson(res, 200, map[string]any{
+			"snapshots": snapshots,
+		})
+	case ("POST"):
+		if !s.checkAdmin(req) {
+			res.WriteHeader(403)
+			return
+		}
+
+		var param struct {
+			Label string `json:"label"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&param); err != nil {
+			res.WriteHeader(400)
+			return
+		}
+		snapshot, err := s.getResourceManager(req).CreateSnapshotWithCreator(param.Label, req.Header.Get("User-Name"))
+		if err != nil {
+			res.WriteHeader(getErrorStatusCode(err))
+			return
+		}
+		res.Header().Set("Location", snapshotPathPrefix+"/"+snapshot.GetLabel())
+		writeJson(res, 201, getSnapshotMap(snapshot))
+	default:
+		res.Header().Set("Allow", "GET, POST")
+		res.WriteHeader(405)
+	}
+}
+
+/*
+/_snapshots/{label}: 스냅샷 조회(GET), 삭제(DELETE)
+삭제는 관리자만 가능
+*/
+func (s *ResourceManagerServer) handleSnapshot(res http.ResponseWriter, req *http.Request) {
+	label := strings.TrimPrefix(req.URL.Path, "/_snapshots/")
+
+	snapshot, ok := s.getResourceManager(req).GetSnapshot(label)
+	if !ok {
+		res.WriteHeader(404)
+		return
+	}
+
+	switch req.Method {
+	case ("GET"):
+		writeJson(res, 200, getSnapshotMap(snapshot))
+	case ("DELETE"):
+		if !s.checkAdmin(req) {
+			res.WriteHeader(403)
+			return
+		}
+		s.getResourceManager(req).DeleteSnapshot(label)
+		res.WriteHeader(204)
+	default:
+		res.Header().Set("Allow", "GET, DELETE")
+		res.WriteHeader(405)
+	}
+}
+
+/*
+스냅샷 경로인지 여부 반환
+*/
+func isSnapshotPath(path string) bool {
+	return path == snapshotPathPrefix || strings.HasPrefix(path, snapshotPathPrefix+"/")
+}
+
+/*
+/.snapshots/{label}/...: 스냅샷의 리소스를 읽기 전용으로 조회(GET, HEAD), 현재 트리로 복원(POST ?restore)
+*/
+func (s *ResourceManagerServer) handleSnapshotPath(res http.ResponseWriter, req *http.Request) {
+	path := getRequestPath(req)
+	allowedMethods := "OPTIONS, GET, HEAD, POST"
+	if path == snapshotPathPrefix {
+		allowedMethods = "OPTIONS, GET, HEAD"
+	}
+
+	switch {
+	case req.Method == "OPTIONS":
+		res.Header().Set("Allow", allowedMethods)
+		res.WriteHeader(200)
+	case path == snapshotPathPrefix && (req.Method == "GET" || req.Method == "HEAD"):
+		s.writeSnapshotListing(res, req)
+	case req.Method == "GET" || req.Method == "HEAD":
+		s.getSnapshotResource(res, req)
+	case req.Method == "POST" && path != snapshotPathPrefix && req.URL.Query().Has("restore"):
+		s.restoreSnapshot(res, req)
+	default:
+		res.Header().Set("Allow", allowedMethods)
+		res.WriteHeader(405)
+	}
+}
+
+/*
+스냅샷 경로에서 스냅샷 이름과 스냅샷 안의 경로 반환
+*/
+func splitSnapshotPath(path string) (string, string) {
+	label, resourcePath, _ := strings.Cut(strings.TrimPrefix(path, snapshotPathPrefix+"/"), "/")
+	return label, "/" + resourcePath
+}
+
+/*
+스냅샷 목록을 폴더처럼 조회
+*/
+func (s *ResourceManagerServer) writeSnapshotListing(res http.ResponseWriter, req *http.Request) {
+	children := []map[string]any{}
+	for _, snapshot := range s.getResourceManager(req).GetSnapshots() {
+		children = append(children, map[string]any{
+			"path":         snapshotPathPrefix + "/" + snapshot.GetLabel(),
+			"name":         snapshot.GetLabel(),
+			"isDirectory":  true,
+			"size":         snapshot.GetSize(),
+			"createdAt":    snapshot.GetCreatedAt().UTC().Format(time.RFC3339),
+			"modifiedAt":   snapshot.GetCreatedAt().UTC().Format(time.RFC3339),
+			"creator":      snapshot.GetCreator(),
+			"lastModifier": snapshot.GetCreator(),
+		})
+	}
+
+	if req.Method == "HEAD" {
+		res.Header().Set("Content-Type", "application/json; charset=utf-8")
+		res.WriteHeader(200)
+		return
+	}
+	writeJson(res, 200, map[string]any{
+		"path":        snapshotPathPrefix,
+		"name":        strings.TrimPrefix(snapshotPathPrefix, "/"),
+		"isDirectory": true,
+		"children":    children,
+	})
+}
+
+/*
+스냅샷의 파일 내용 또는 폴더의 하위 리소스 목록 조회
+권한은 스냅샷을 만들 때의 권한으로 확인
+*/
+func (s *ResourceManagerServer) getSnapshotResource(res http.ResponseWriter, req *http.Request) {
+	username := req.Header.Get("User-Name")
+	groupname := req.Header.Get("Group-Name")
+	label, path := splitSnapshotPath(getRequestPath(req))
+
+	statusCode := 0
+	etag := ""
+	var modifiedAt time.Time
+	var content io.ReadSeekCloser
+	var listing map[string]any
+	s.getResourceManager(req).View(func(m *class.ResourceManager) {
+		snapshot, ok := m.GetSnapshot(label)
+		if !ok {
+			statusCode = 404
+			return
+		}
+		resourceObject := snapshot.GetResourceObject(path)
+		if resourceObject == nil {
+			statusCode = 404
+			return
+		}
+
+		// 권한 확인
+		if !s.checkPermission(req, resourceObject, "read") {
+			statusCode = 403
+			return
+		}
+
+		etag = resourceObject.GetETag()
+		modifiedAt = resourceObject.GetModifiedAt()
+		if statusCode = checkPreconditions(req, resourceObject); statusCode != 0 {
+			return
+		}
+
+		if resourceObject.IsFile() {
+			var err error
+			if content, err = m.OpenSnapshotContent(label, path); err != nil {
+				statusCode = getErrorStatusCode(err)
+			}
+			return
+		}
+
+		children := []map[string]any{}
+		for _, child := range resourceObject.GetChildren() {
+			if !child.CheckPermission(username, groupname, "read") {
+				continue
+			}
+			children = append(children, getSnapshotMetadataMap(label, child))
+		}
+		listing = getSnapshotMetadataMap(label, resourceObject)
+		listing["children"] = children
+	})
+
+	if etag != "" {
+		res.Header().Set("ETag", etag)
+		res.Header().Set("Last-Modified", modifiedAt.UTC().Format(http.TimeFormat))
+	}
+	if statusCode != 0 {
+		res.WriteHeader(statusCode)
+		return
+	}
+
+	if content != nil {
+		defer content.Close()
+		_, name := filepath.Split(path)
+		serveContent(res, req, name, modifiedAt, content)
+		return
+	}
+
+	if req.Method == "HEAD" {
+		res.Header().Set("Content-Type", "application/json; charset=utf-8")
+		res.WriteHeader(200)
+		return
+	}
+	writeJson(res, 200, listing)
+}
+
+/*
+스냅샷의 리소스를 원래 경로 또는 `Destination` 헤더의 경로로 복원
+경로에 리소스가 있으면 `Overwrite: F`가 아닐 때 덮어씀
+*/
+func (s *ResourceManagerServer) restoreSnapshot(res http.ResponseWriter, req *http.Request) {
+	username := req.Header.Get("User-Name")
+	label, path := splitSnapshotPath(getRequestPath(req))
+
+	destinationPath := path
+	if req.Header.Get("Destination") != "" {
+		var ok bool
+		destinationPath, ok = getDestinationPath(req)
+		if !ok || isSnapshotPath(destinationPath) {
+			res.WriteHeader(400)
+			return
+		}
+	}
+	isOverwrite := req.Header.Get("Overwrite") != "F"
+
+	statusCode := 0
+	s.getResourceManager(req).View(func(m *class.ResourceManager) {
+		snapshot, ok := m.GetSnapshot(label)
+		if !ok {
+			statusCode = 404
+			return
+		}
+		resourceObject := snapshot.GetResourceObject(path)
+		if resourceObject == nil {
+			statusCode = 404
+			return
+		}
+
+		// 권한 확인
+		if !s.checkPermission(req, resourceObject, "read") {
+			statusCode = 403
+			return
+		}
+		statusCode = s.checkDestination(req, m, destinationPath, isOverwrite)
+	})
+	if statusCode != 0 {
+		res.WriteHeader(statusCode)
+		return
+	}
+
+	// 리소스 복원
+	isOverwritten, err := s.getResourceManager(req).RestoreSnapshotWithLockTokens(label, path, destinationPath, isOverwrite, username, getSubmittedLockTokens(req))
+	if err != nil {
+		if errors.Is(err, class.ErrAlreadyExists) {
+			res.WriteHeader(412)
+		} else {
+			res.WriteHeader(getErrorStatusCode(err))
+		}
+		return
+	}
+
+	res.Header().Set("Location", destinationPath)
+	if isOverwritten {
+		res.WriteHeader(204)
+	} else {
+		res.WriteHeader(201)
+	}
+}
+
+/*
+스냅샷을 응답용 map으로 변환
+*/
+func getSnapshotMap(snapshot class.ResourceSnapshot) map[string]any {
+	return map[string]any{
+		"label":     snapshot.GetLabel(),
+		"path":      snapshotPathPrefix + "/" + snapshot.GetLabel(),
+		"size":      snapshot.GetSize(),
+		"creator":   snapshot.GetCreator(),
+		"createdAt": snapshot.GetCreatedAt().UTC().Format(time.RFC3339),
+	}
+}
+
+/*
+스냅샷의 리소스 메타데이터를 응답용 map으로 변환
+경로는 스냅샷 경로로 변환
+*/
+func getSnapshotMetadataMap(label string, resourceObject *class.ResourceObject) map[string]any {
+	metadataMap := getResourceMetadataMap(resourceObject)
+	path := snapshotPathPrefix + "/" + label
+	if resourceObject.GetPath() != "/" {
+		path += resourceObject.GetPath()
+	}
+	metadataMap["path"] = path
+	return metadataMap
+}
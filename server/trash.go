@@ -0,0 +1,195 @@
+package app
+
+import (
+	"app/class"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+/*
+/_trash: 휴지통 목록 조회(GET), 비우기(DELETE)
+관리자가 아니면 자신이 삭제한 항목만 대상으로 함
+관리자는 `user` 쿼리로 해당 유저가 삭제한 항목만 대상으로 지정 가능
+유저 이름이 없는 요청은 관리자가 아니면 사용할 수 없음
+*/
+func (s *ResourceManagerServer) handleTrash(res http.ResponseWriter, req *http.Request) {
+	// 빈 문자열은 모든 항목을 뜻하므로 관리자만 사용
+	deleter := req.Header.Get("User-Name")
+	if s.isAdmin(req) {
+		deleter = req.URL.Query().Get("user")
+	} else if deleter == "" {
+		res.WriteHeader(403)
+		return
+	}
+
+	switch req.Method {
+	case ("GET"):
+		itemMaps := []map[string]any{}
+		s.getResourceManager(req).View(func(m *class.ResourceManager) {
+			itemMaps = trashItemListToMaps(m.GetTrashItems(deleter), m.GetTrashPolicy())
+		})
+		writeJson(res, 200, map[string]any{
+			"items": itemMaps,
+		})
+	case ("DELETE"):
+		// 영구 삭제한 항목은 트리에서 떨어져 있으므로 잠금 없이 읽어도 됨
+		items := s.getResourceManager(req).PurgeTrash(deleter)
+		writeJson(res, 200, map[string]any{
+			"purged": trashItemListToMaps(items, s.getResourceManager(req).GetTrashPolicy()),
+		})
+	default:
+		res.Header().Set("Allow", "GET, DELETE")
+		res.WriteHeader(405)
+	}
+}
+
+/*
+/_trash/{id}: 휴지통 항목 조회(GET), 복원(POST), 영구 삭제(DELETE)
+삭제한 유저 또는 관리자만 사용 가능, 유저 이름이 없는 요청은 관리자가 아니면 사용할 수 없음
+*/
+func (s *ResourceManagerServer) handleTrashItem(res http.ResponseWriter, req *http.Request) {
+	id := strings.TrimPrefix(req.URL.Path, "/_trash/")
+
+	username := req.Header.Get("User-Name")
+	isAdmin := s.isAdmin(req)
+	if username == "" && !isAdmin {
+		res.WriteHeader(403)
+		return
+	}
+	var item class.TrashItem
+	var itemMap map[string]any
+	isFound := false
+	s.getResourceManager(req).View(func(m *class.ResourceManager) {
+		var ok bool
+		item, ok = m.GetTrashItem(id)
+		if !ok || (item.GetDeleter() != username && !isAdmin) {
+			return
+		}
+		isFound = true
+		itemMap = getTrashItemMap(item, m.GetTrashPolicy())
+	})
+	if !isFound {
+		res.WriteHeader(404)
+		return
+	}
+
+	switch req.Method {
+	case ("GET"):
+		writeJson(res, 200, itemMap)
+	case ("POST"):
+		s.restoreTrashItem(res, req, item)
+	case ("DELETE"):
+		s.getResourceManager(req).PurgeTrashItem(id)
+		res.WriteHeader(204)
+	default:
+		res.Header().Set("Allow", "GET, POST, DELETE")
+		res.WriteHeader(405)
+	}
+}
+
+/*
+휴지통 항목을 원래 경로 또는 `Destination` 헤더의 경로로 복원
+경로에 리소스가 있으면 `Overwrite: T`일 때만 덮어씀
+*/
+func (s *ResourceManagerServer) restoreTrashItem(res http.ResponseWriter, req *http.Request, item class.TrashItem) {
+	username := req.Header.Get("User-Name")
+
+	destinationPath := item.GetOriginalPath()
+	if req.Header.Get("Destination") != "" {
+		var ok bool
+		destinationPath, ok = getDestinationPath(req)
+		if !ok {
+			res.WriteHeader(400)
+			return
+		}
+	}
+	isOverwrite := req.Header.Get("Overwrite") == "T"
+
+	// 권한 확인
+	statusCode := 0
+	s.getResourceManager(req).View(func(m *class.ResourceManager) {
+		statusCode = s.checkDestination(req, m, destinationPath, isOverwrite)
+	})
+	if statusCode != 0 {
+		res.WriteHeader(statusCode)
+		return
+	}
+
+	// 리소스 복원
+	isOverwritten, err := s.getResourceManager(req).RestoreTrashItemWithLockTokens(item.GetId(), destinationPath, isOverwrite, username, getSubmittedLockTokens(req))
+	if err != nil {
+		if errors.Is(err, class.ErrAlreadyExists) {
+			res.WriteHeader(412)
+		} else {
+			res.WriteHeader(getErrorStatusCode(err))
+		}
+		return
+	}
+
+	res.Header().Set("Location", destinationPath)
+	if isOverwritten {
+		res.WriteHeader(204)
+	} else {
+		res.WriteHeader(201)
+	}
+}
+
+/*
+/_admin/trash: 휴지통 보관 정책 조회(GET), 변경(PUT)
+*/
+func (s *ResourceManagerServer) handleAdminTrash(res http.ResponseWriter, req *http.Request) {
+	if !s.checkAdmin(req) {
+		res.WriteHeader(403)
+		return
+	}
+
+	switch req.Method {
+	case ("GET"):
+		writeJson(res, 200, s.getResourceManager(req).GetTrashPolicy().ToMap())
+	case ("PUT"):
+		var policyMap map[string]any
+		if err := json.NewDecoder(req.Body).Decode(&policyMap); err != nil {
+			res.WriteHeader(400)
+			return
+		}
+		s.getResourceManager(req).SetTrashPolicy(class.FromMapTrashPolicy(policyMap))
+		writeJson(res, 200, s.getResourceManager(req).GetTrashPolicy().ToMap())
+	default:
+		res.WriteHeader(405)
+	}
+}
+
+/*
+휴지통 항목을 응답용 map으로 변환
+View 안에서 호출
+*/
+func getTrashItemMap(item class.TrashItem, trashPolicy class.TrashPolicy) map[string]any {
+	expiresAt := ""
+	if maxAge := trashPolicy.MaxAge; maxAge > 0 {
+		expiresAt = item.GetDeletedAt().Add(maxAge).UTC().Format(time.RFC3339)
+	}
+
+	return map[string]any{
+		"id":           item.GetId(),
+		"originalPath": item.GetOriginalPath(),
+		"deleter":      item.GetDeleter(),
+		"deletedAt":    item.GetDeletedAt().UTC().Format(time.RFC3339),
+		"expiresAt":    expiresAt,
+		"resource":     getResourceMetadataMap(item.GetResourceObject()),
+	}
+}
+
+/*
+휴지통 항목 목록을 응답용 map 목록으로 변환
+휴지통에 있는 항목은 View 안에서 호출
+*/
+func trashItemListToMaps(items []class.TrashItem, trashPolicy class.TrashPolicy) []map[string]any {
+	itemMaps := []map[string]any{}
+	for _, item := range items {
+		itemMaps = append(itemMaps, getTrashItemMap(item, trashPolicy))
+	}
+	return itemMaps
+}
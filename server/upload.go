@@ -0,0 +1,281 @@
+package app
+
+import (
+	"app/class"
+	"app/util"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 마지막 요청 이후 업로드 세션이 유지되는 시간
+const uploadSessionTimeout = 24 * time.Hour
+
+/*
+분할 업로드 세션
+커밋하기 전까지 받은 내용은 임시 파일에 저장하며 리소스에는 반영하지 않음
+*/
+type uploadSession struct {
+	id   string
+	path string
+	// 세션을 생성한 유저, 해당 유저만 세션을 사용할 수 있음
+	owner string
+	file  *os.File
+	// 지금까지 받은 크기
+	offset int64
+	// 전체 크기, 모르면 -1
+	size      int64
+	updatedAt time.Time
+	mutex     sync.Mutex
+}
+
+type uploadSessionParam struct {
+	Path string `json:"path"`
+	// 전체 크기, 모르면 생략
+	Size *int64 `json:"size"`
+}
+
+var contentRangePattern = regexp.MustCompile(`^bytes (\d+)-(\d+)/(\d+|\*)$`)
+
+/*
+세션 정보를 응답용 map으로 변환
+*/
+func (u *uploadSession) toMap() map[string]any {
+	return map[string]any{
+		"id":        u.id,
+		"path":      u.path,
+		"offset":    u.offset,
+		"size":      u.size,
+		"expiresAt": u.updatedAt.Add(uploadSessionTimeout).UTC().Format(time.RFC3339),
+	}
+}
+
+/*
+세션의 임시 파일 삭제
+*/
+func (u *uploadSession) close() {
+	u.file.Close()
+	os.Remove(u.file.Name())
+}
+
+/*
+/_uploads: 업로드 세션 생성(POST)
+*/
+func (s *ResourceManagerServer) handleUploads(res http.ResponseWriter, req *http.Request) {
+	if req.Method != "POST" {
+		res.Header().Set("Allow", "POST")
+		res.WriteHeader(405)
+		return
+	}
+
+	username := req.Header.Get("User-Name")
+	groupname := req.Header.Get("Group-Name")
+
+	var param uploadSessionParam
+	if err := json.NewDecoder(req.Body).Decode(&param); err != nil {
+		res.WriteHeader(400)
+		return
+	}
+	path := strings.TrimSuffix(param.Path, "/")
+	if !util.IsValidPath(path) || (param.Size != nil && *param.Size < 0) {
+		res.WriteHeader(400)
+		return
+	}
+
+	// 커밋할 때 다시 확인하지만 미리 실패할 요청은 거절
+	statusCode := 0
+	s.getResourceManager(req).View(func(m *class.ResourceManager) {
+		if statusCode = s.checkWritableFile(req, m, path); statusCode != 0 {
+			return
+		}
+		if param.Size != nil {
+			if err := m.CheckContentQuota(path, username, groupname, *param.Size); err != nil {
+				statusCode = getErrorStatusCode(err)
+			}
+		}
+	})
+	if statusCode != 0 {
+		res.WriteHeader(statusCode)
+		return
+	}
+
+	file, err := os.CreateTemp("", "upload-*")
+	if err != nil {
+		res.WriteHeader(500)
+		return
+	}
+	session := &uploadSession{
+		id:        util.GenerateRandomString(32),
+		path:      path,
+		owner:     username,
+		file:      file,
+		size:      -1,
+		updatedAt: time.Now(),
+	}
+	if param.Size != nil {
+		session.size = *param.Size
+	}
+
+	s.uploadMutex.Lock()
+	s.removeExpiredUploadSessions()
+	s.uploadSessions[session.id] = session
+	s.uploadMutex.Unlock()
+
+	res.Header().Set("Location", "/_uploads/"+session.id)
+	writeJson(res, 201, session.toMap())
+}
+
+/*
+/_uploads/{id}: 업로드 세션 조회(GET), 내용 추가(PUT), 커밋(POST), 취소(DELETE)
+*/
+func (s *ResourceManagerServer) handleUploadSession(res http.ResponseWriter, req *http.Request) {
+	id := strings.TrimPrefix(req.URL.Path, "/_uploads/")
+
+	s.uploadMutex.Lock()
+	session := s.uploadSessions[id]
+	s.uploadMutex.Unlock()
+	if session == nil || session.owner != req.Header.Get("User-Name") {
+		res.WriteHeader(404)
+		return
+	}
+
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+
+	// 대기하는 동안 커밋, 취소되었거나 만료된 세션
+	s.uploadMutex.Lock()
+	isAlive := s.uploadSessions[id] == session
+	s.uploadMutex.Unlock()
+	if !isAlive {
+		res.WriteHeader(404)
+		return
+	}
+	session.updatedAt = time.Now()
+
+	switch req.Method {
+	case ("GET"):
+		res.Header().Set("Upload-Offset", strconv.FormatInt(session.offset, 10))
+		writeJson(res, 200, session.toMap())
+	case ("PUT"):
+		s.writeUploadChunk(res, req, session)
+	case ("POST"):
+		s.commitUploadSession(res, req, session)
+	case ("DELETE"):
+		s.removeUploadSession(session)
+		res.WriteHeader(204)
+	default:
+		res.Header().Set("Allow", "GET, PUT, POST, DELETE")
+		res.WriteHeader(405)
+	}
+}
+
+/*
+`Content-Range` 헤더의 범위에 요청 본문을 기록
+범위는 지금까지 받은 크기에서 시작해야 함
+*/
+func (s *ResourceManagerServer) writeUploadChunk(res http.ResponseWriter, req *http.Request, session *uploadSession) {
+	matches := contentRangePattern.FindStringSubmatch(req.Header.Get("Content-Range"))
+	if matches == nil {
+		res.WriteHeader(400)
+		return
+	}
+	start, _ := strconv.ParseInt(matches[1], 10, 64)
+	end, err := strconv.ParseInt(matches[2], 10, 64)
+	if err != nil || end < start {
+		res.WriteHeader(400)
+		return
+	}
+	size := session.size
+	if matches[3] != "*" {
+		size, err = strconv.ParseInt(matches[3], 10, 64)
+		if err != nil || (session.size >= 0 && size != session.size) {
+			res.WriteHeader(400)
+			return
+		}
+	}
+	if size >= 0 && end >= size {
+		res.WriteHeader(400)
+		return
+	}
+
+	res.Header().Set("Upload-Offset", strconv.FormatInt(session.offset, 10))
+	if start != session.offset {
+		res.WriteHeader(409)
+		return
+	}
+
+	// 본문 길이가 범위와 다르면 기록한 내용을 되돌림
+	length := end - start + 1
+	written, err := io.Copy(session.file, io.LimitReader(req.Body, length+1))
+	if err != nil || written != length {
+		session.file.Truncate(session.offset)
+		session.file.Seek(session.offset, io.SeekStart)
+		res.WriteHeader(400)
+		return
+	}
+
+	session.offset += length
+	session.size = size
+	res.Header().Set("Upload-Offset", strconv.FormatInt(session.offset, 10))
+	res.WriteHeader(204)
+}
+
+/*
+받은 내용을 세션의 경로에 저장하고 세션 종료
+저장에 실패하면 세션을 유지하여 다시 커밋 가능
+*/
+func (s *ResourceManagerServer) commitUploadSession(res http.ResponseWriter, req *http.Request, session *uploadSession) {
+	if session.size >= 0 && session.offset != session.size {
+		res.Header().Set("Upload-Offset", strconv.FormatInt(session.offset, 10))
+		res.WriteHeader(409)
+		return
+	}
+
+	if _, err := session.file.Seek(0, io.SeekStart); err != nil {
+		res.WriteHeader(500)
+		return
+	}
+	if s.putContent(res, req, session.path, io.LimitReader(session.file, session.offset)) {
+		s.removeUploadSession(session)
+		return
+	}
+
+	// 이어서 내용을 추가할 수 있도록 위치 복원
+	if _, err := session.file.Seek(session.offset, io.SeekStart); err != nil {
+		s.removeUploadSession(session)
+	}
+}
+
+/*
+세션을 목록에서 제거하고 임시 파일 삭제
+*/
+func (s *ResourceManagerServer) removeUploadSession(session *uploadSession) {
+	s.uploadMutex.Lock()
+	delete(s.uploadSessions, session.id)
+	s.uploadMutex.Unlock()
+	session.close()
+}
+
+/*
+만료된 세션 제거
+uploadMutex를 잠근 상태에서 호출
+*/
+func (s *ResourceManagerServer) removeExpiredUploadSessions() {
+	now := time.Now()
+	for id, session := range s.uploadSessions {
+		if !session.mutex.TryLock() {
+			continue
+		}
+		if now.Sub(session.updatedAt) >= uploadSessionTimeout {
+			delete(s.uploadSessions, id)
+			session.close()
+		}
+		session.mutex.Unlock()
+	}
+}
@@ -0,0 +1,185 @@
+package app
+
+import (
+	"app/class"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+/*
+GET ?versions: 파일의 현재 버전과 보관된 이전 버전 목록 조회
+*/
+func (s *ResourceManagerServer) writeVersions(res http.ResponseWriter, req *http.Request, path string) {
+	statusCode := 0
+	var versionsMap map[string]any
+	s.getResourceManager(req).View(func(m *class.ResourceManager) {
+		resourceObject, readStatusCode := s.getReadableResourceObject(req, m, path)
+		if resourceObject == nil {
+			statusCode = readStatusCode
+			return
+		}
+		if !resourceObject.IsFile() {
+			statusCode = 405
+			return
+		}
+
+		currentVersion, _ := resourceObject.GetVersionByNumber(resourceObject.GetVersion())
+		versions := []map[string]any{getVersionMap(currentVersion, true)}
+		for _, version := range resourceObject.GetVersions() {
+			versions = append(versions, getVersionMap(version, false))
+		}
+		versionsMap = map[string]any{
+			"path":     resourceObject.GetPath(),
+			"version":  resourceObject.GetVersion(),
+			"versions": versions,
+		}
+	})
+	if statusCode != 0 {
+		res.WriteHeader(statusCode)
+		return
+	}
+
+	writeJson(res, 200, versionsMap)
+}
+
+/*
+GET ?version={number}: 파일의 특정 버전 내용 조회
+*/
+func (s *ResourceManagerServer) writeVersionContent(res http.ResponseWriter, req *http.Request, path string) {
+	number, err := strconv.ParseInt(req.URL.Query().Get("version"), 10, 64)
+	if err != nil {
+		res.WriteHeader(400)
+		return
+	}
+
+	statusCode := 0
+	name := ""
+	var version class.ResourceVersion
+	var content io.ReadSeekCloser
+	s.getResourceManager(req).View(func(m *class.ResourceManager) {
+		resourceObject, readStatusCode := s.getReadableResourceObject(req, m, path)
+		if resourceObject == nil {
+			statusCode = readStatusCode
+			return
+		}
+		if !resourceObject.IsFile() {
+			statusCode = 405
+			return
+		}
+		var ok bool
+		if version, ok = resourceObject.GetVersionByNumber(number); !ok {
+			statusCode = 404
+			return
+		}
+
+		name = resourceObject.GetName()
+		var err error
+		if content, err = m.OpenVersionContent(path, number); err != nil {
+			statusCode = getErrorStatusCode(err)
+		}
+	})
+	if statusCode != 0 {
+		res.WriteHeader(statusCode)
+		return
+	}
+	defer content.Close()
+
+	res.Header().Set("ETag", version.GetETag())
+	serveContent(res, req, name, version.GetModifiedAt(), content)
+}
+
+/*
+POST ?restore={number}: 파일의 이전 버전을 현재 내용으로 복원
+*/
+func (s *ResourceManagerServer) restoreVersion(res http.ResponseWriter, req *http.Request) {
+	username := req.Header.Get("User-Name")
+	path := getRequestPath(req)
+
+	number, err := strconv.ParseInt(req.URL.Query().Get("restore"), 10, 64)
+	if err != nil {
+		res.WriteHeader(400)
+		return
+	}
+
+	statusCode := 0
+	s.getResourceManager(req).View(func(m *class.ResourceManager) {
+		resourceObject := m.GetResourceObject(path)
+		if resourceObject == nil {
+			statusCode = 404
+			return
+		}
+		if statusCode = checkPreconditions(req, resourceObject); statusCode != 0 {
+			return
+		}
+
+		// 권한 확인
+		if !s.checkPermission(req, resourceObject, "write") {
+			statusCode = 403
+		}
+	})
+	if statusCode != 0 {
+		res.WriteHeader(statusCode)
+		return
+	}
+
+	err = s.getResourceManager(req).RestoreVersionWithLockTokens(path, number, username, getSubmittedLockTokens(req))
+	if err != nil {
+		res.WriteHeader(getErrorStatusCode(err))
+		return
+	}
+
+	s.getResourceManager(req).View(func(m *class.ResourceManager) {
+		if resourceObject := m.GetResourceObject(path); resourceObject != nil {
+			res.Header().Set("ETag", resourceObject.GetETag())
+		}
+	})
+	res.WriteHeader(204)
+}
+
+/*
+/_admin/versions: 이전 버전 보관 정책 조회(GET), 변경(PUT), 정책을 벗어난 버전 정리(POST)
+*/
+func (s *ResourceManagerServer) handleAdminVersions(res http.ResponseWriter, req *http.Request) {
+	if !s.checkAdmin(req) {
+		res.WriteHeader(403)
+		return
+	}
+
+	switch req.Method {
+	case ("GET"):
+		writeJson(res, 200, s.getResourceManager(req).GetVersionPolicy().ToMap())
+	case ("PUT"):
+		var policyMap map[string]any
+		if err := json.NewDecoder(req.Body).Decode(&policyMap); err != nil {
+			res.WriteHeader(400)
+			return
+		}
+		s.getResourceManager(req).SetVersionPolicy(class.FromMapVersionPolicy(policyMap))
+		writeJson(res, 200, s.getResourceManager(req).GetVersionPolicy().ToMap())
+	case ("POST"):
+		prunedCount, deletedCount := s.getResourceManager(req).PruneVersions()
+		writeJson(res, 200, map[string]any{
+			"prunedVersions": prunedCount,
+			"deletedBlobs":   deletedCount,
+		})
+	default:
+		res.WriteHeader(405)
+	}
+}
+
+/*
+버전을 응답용 map으로 변환
+*/
+func getVersionMap(version class.ResourceVersion, isCurrent bool) map[string]any {
+	return map[string]any{
+		"version":    version.GetNumber(),
+		"etag":       version.GetETag(),
+		"size":       version.GetSize(),
+		"modifier":   version.GetModifier(),
+		"modifiedAt": version.GetModifiedAt().UTC().Format(time.RFC3339),
+		"isCurrent":  isCurrent,
+	}
+}
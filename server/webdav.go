@@ -0,0 +1,260 @@
+package app
+
+import (
+	"app/class"
+	"encoding/xml"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/*
+WebDAV 응답 XML 구조
+*/
+type davMultistatus struct {
+	XMLName   xml.Name      `xml:"D:multistatus"`
+	XmlnsD    string        `xml:"xmlns:D,attr"`
+	Responses []davResponse `xml:"D:response"`
+}
+
+type davResponse struct {
+	Href      string        `xml:"D:href"`
+	Propstats []davPropstat `xml:"D:propstat"`
+}
+
+type davPropstat struct {
+	Prop   davProp `xml:"D:prop"`
+	Status string  `xml:"D:status"`
+}
+
+type davProp struct {
+	XMLName    xml.Name `xml:"D:prop"`
+	XmlnsD     string   `xml:"xmlns:D,attr,omitempty"`
+	Properties []davProperty
+}
+
+/*
+속성 하나
+DAV: 네임스페이스의 속성은 XMLName.Local에 "D:" 접두어를 붙이고 XMLName.Space는 비워둠
+*/
+type davProperty struct {
+	XMLName  xml.Name
+	InnerXML string `xml:",innerxml"`
+}
+
+type davActiveLock struct {
+	XMLName   xml.Name     `xml:"D:activelock"`
+	LockType  davLockType  `xml:"D:locktype"`
+	LockScope davLockScope `xml:"D:lockscope"`
+	Depth     string       `xml:"D:depth"`
+	Owner     string       `xml:"D:owner,omitempty"`
+	Timeout   string       `xml:"D:timeout"`
+	LockToken davHref      `xml:"D:locktoken"`
+	LockRoot  davHref      `xml:"D:lockroot"`
+}
+
+type davLockType struct {
+	Write *struct{} `xml:"D:write"`
+}
+
+type davLockScope struct {
+	Exclusive *struct{} `xml:"D:exclusive,omitempty"`
+	Shared    *struct{} `xml:"D:shared,omitempty"`
+}
+
+type davHref struct {
+	Href string `xml:"D:href"`
+}
+
+type davCollection struct {
+	XMLName xml.Name `xml:"D:collection"`
+}
+
+type davLockEntry struct {
+	XMLName   xml.Name     `xml:"D:lockentry"`
+	LockScope davLockScope `xml:"D:lockscope"`
+	LockType  davLockType  `xml:"D:locktype"`
+}
+
+/*
+WebDAV 요청 XML 구조
+*/
+type davPropfind struct {
+	XMLName  xml.Name      `xml:"DAV: propfind"`
+	AllProp  *struct{}     `xml:"DAV: allprop"`
+	PropName *struct{}     `xml:"DAV: propname"`
+	Prop     *davPropNames `xml:"DAV: prop"`
+}
+
+type davPropNames struct {
+	Names []davPropName `xml:",any"`
+}
+
+type davPropName struct {
+	XMLName xml.Name
+}
+
+type davPropertyUpdate struct {
+	XMLName      xml.Name                 `xml:"DAV: propertyupdate"`
+	Instructions []davPropertyInstruction `xml:",any"`
+}
+
+// set 또는 remove
+type davPropertyInstruction struct {
+	XMLName xml.Name
+	Prop    davPropValues `xml:"DAV: prop"`
+}
+
+type davPropValues struct {
+	Values []davPropValue `xml:",any"`
+}
+
+type davPropValue struct {
+	XMLName  xml.Name
+	InnerXML string `xml:",innerxml"`
+}
+
+type davLockInfo struct {
+	XMLName   xml.Name `xml:"DAV: lockinfo"`
+	LockScope struct {
+		Exclusive *struct{} `xml:"DAV: exclusive"`
+		Shared    *struct{} `xml:"DAV: shared"`
+	} `xml:"DAV: lockscope"`
+}
+
+/*
+DAV: 네임스페이스의 문자열 속성 생성
+*/
+func newDavTextProperty(name string, text string) davProperty {
+	return davProperty{
+		XMLName:  xml.Name{Local: "D:" + name},
+		InnerXML: escapeXmlText(text),
+	}
+}
+
+/*
+문자열을 XML 텍스트로 이스케이프
+*/
+func escapeXmlText(text string) string {
+	var escaped strings.Builder
+	xml.EscapeText(&escaped, []byte(text))
+	return escaped.String()
+}
+
+/*
+DAV: 네임스페이스의 XML 속성 생성
+values의 각 값을 XML로 변환하여 속성의 하위 요소로 사용
+*/
+func newDavXmlProperty(name string, values ...any) davProperty {
+	innerXml := ""
+	for _, value := range values {
+		data, err := xml.Marshal(value)
+		if err != nil {
+			continue
+		}
+		innerXml += string(data)
+	}
+	return davProperty{
+		XMLName:  xml.Name{Local: "D:" + name},
+		InnerXML: innerXml,
+	}
+}
+
+/*
+리소스의 잠금 목록을 lockdiscovery 속성으로 변환
+*/
+func newDavLockDiscovery(locks []class.ResourceLock) davProperty {
+	activeLocks := []any{}
+	now := time.Now()
+
+	for _, lock := range locks {
+		activeLock := davActiveLock{
+			LockType:  davLockType{Write: &struct{}{}},
+			Depth:     "0",
+			Owner:     lock.GetOwner(),
+			Timeout:   "Infinite",
+			LockToken: davHref{Href: "opaquelocktoken:" + lock.GetToken()},
+			LockRoot:  davHref{Href: lock.GetRoot()},
+		}
+		if lock.GetScope() == class.LOCK_SCOPE_SHARED {
+			activeLock.LockScope.Shared = &struct{}{}
+		} else {
+			activeLock.LockScope.Exclusive = &struct{}{}
+		}
+		if lock.IsDepthInfinity() {
+			activeLock.Depth = "infinity"
+		}
+		if !lock.GetExpiresAt().IsZero() {
+			seconds := int(lock.GetExpiresAt().Sub(now).Seconds())
+			if seconds < 0 {
+				seconds = 0
+			}
+			activeLock.Timeout = "Second-" + strconv.Itoa(seconds)
+		}
+		activeLocks = append(activeLocks, activeLock)
+	}
+
+	return newDavXmlProperty("lockdiscovery", activeLocks...)
+}
+
+/*
+지원하는 잠금 종류를 supportedlock 속성으로 반환
+*/
+func newDavSupportedLock() davProperty {
+	return newDavXmlProperty(
+		"supportedlock",
+		davLockEntry{
+			LockScope: davLockScope{Exclusive: &struct{}{}},
+			LockType:  davLockType{Write: &struct{}{}},
+		},
+		davLockEntry{
+			LockScope: davLockScope{Shared: &struct{}{}},
+			LockType:  davLockType{Write: &struct{}{}},
+		},
+	)
+}
+
+/*
+`Timeout` 헤더에서 잠금 만료 시간 반환
+`Second-3600, Infinite` 처럼 여러 값이 있으면 처음으로 해석 가능한 값 사용
+  - @return {time.Duration} 만료 시간, 만료되지 않으면 0
+*/
+func getLockTimeout(req *http.Request) time.Duration {
+	for _, value := range strings.Split(req.Header.Get("Timeout"), ",") {
+		value = strings.TrimSpace(value)
+		if value == "Infinite" {
+			return 0
+		}
+		if strings.HasPrefix(value, "Second-") {
+			seconds, err := strconv.Atoi(strings.TrimPrefix(value, "Second-"))
+			if err == nil && seconds > 0 {
+				return time.Duration(seconds) * time.Second
+			}
+		}
+	}
+	return 0
+}
+
+/*
+응답 코드에 해당하는 WebDAV status 문자열 반환
+*/
+func getDavStatus(statusCode int) string {
+	return "HTTP/1.1 " + strconv.Itoa(statusCode) + " " + http.StatusText(statusCode)
+}
+
+/*
+XML 응답 작성
+*/
+func writeXml(res http.ResponseWriter, statusCode int, body any) {
+	data, err := xml.Marshal(body)
+	if err != nil {
+		res.WriteHeader(500)
+		return
+	}
+
+	res.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	res.WriteHeader(statusCode)
+	res.Write([]byte(xml.Header))
+	res.Write(data)
+}
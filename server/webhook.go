@@ -0,0 +1,178 @@
+package app
+
+import (
+	"app/webhook"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+/*
+웹훅 전송기 교체
+기존 전송기는 닫으며, 진행 중인 전송은 중단되어 기존 전송기의 실패 목록으로 옮겨짐
+*/
+func (s *ResourceManagerServer) SetWebhookDispatcher(dispatcher *webhook.Dispatcher) {
+	if s.webhooks != nil {
+		s.webhooks.Close()
+	}
+	s.webhooks = dispatcher
+}
+
+/*
+웹훅 전송기 반환
+*/
+func (s *ResourceManagerServer) GetWebhookDispatcher() *webhook.Dispatcher {
+	return s.webhooks
+}
+
+/*
+/_admin/webhooks: 웹훅 구독 목록 조회(GET), 구독 추가(POST)
+*/
+func (s *ResourceManagerServer) handleAdminWebhooks(res http.ResponseWriter, req *http.Request) {
+	if !s.checkAdmin(req) {
+		res.WriteHeader(403)
+		return
+	}
+
+	switch req.Method {
+	case ("GET"):
+		subscriptions := []map[string]any{}
+		for _, subscription := range s.webhooks.GetSubscriptions() {
+			subscriptions = append(subscriptions, subscription.ToMap())
+		}
+		writeJson(res, 200, map[string]any{
+			"subscriptions": subscriptions,
+		})
+	case ("POST"):
+		var param struct {
+			Url        string   `json:"url"`
+			PathPrefix string   `json:"pathPrefix"`
+			EventTypes []string `json:"eventTypes"`
+			Secret     string   `json:"secret"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&param); err != nil {
+			res.WriteHeader(400)
+			return
+		}
+		subscription, err := s.webhooks.AddSubscription(webhook.SubscriptionParam{
+			Url:        param.Url,
+			PathPrefix: param.PathPrefix,
+			EventTypes: param.EventTypes,
+			Secret:     param.Secret,
+		})
+		if err != nil {
+			res.WriteHeader(getErrorStatusCode(err))
+			return
+		}
+
+		// 비밀 값은 생성할 때만 응답
+		subscriptionMap := subscription.ToMap()
+		subscriptionMap["secret"] = subscription.GetSecret()
+		writeJson(res, 201, subscriptionMap)
+	default:
+		res.Header().Set("Allow", "GET, POST")
+		res.WriteHeader(405)
+	}
+}
+
+/*
+/_admin/webhooks/{id}: 웹훅 구독 조회(GET), 삭제(DELETE)
+/_admin/webhooks/{id}/deliveries: 전송 기록 조회(GET)
+/_admin/webhooks/{id}/dead-letters: 모든 시도가 실패한 전송 목록 조회(GET)
+/_admin/webhooks/{id}/dead-letters/{deliveryId}: 실패한 전송 다시 전송(POST), 목록에서 삭제(DELETE)
+*/
+func (s *ResourceManagerServer) handleAdminWebhook(res http.ResponseWriter, req *http.Request) {
+	if !s.checkAdmin(req) {
+		res.WriteHeader(403)
+		return
+	}
+
+	names := strings.Split(strings.TrimPrefix(req.URL.Path, "/_admin/webhooks/"), "/")
+	subscription, ok := s.webhooks.GetSubscription(names[0])
+	if !ok {
+		res.WriteHeader(404)
+		return
+	}
+
+	switch {
+	case len(names) == 1:
+		s.handleAdminWebhookSubscription(res, req, subscription)
+	case len(names) == 2 && names[1] == "deliveries":
+		if req.Method != "GET" {
+			res.Header().Set("Allow", "GET")
+			res.WriteHeader(405)
+			return
+		}
+		writeJson(res, 200, map[string]any{
+			"deliveries": deliveryListToMaps(s.webhooks.GetDeliveries(subscription.GetId())),
+		})
+	case len(names) == 2 && names[1] == "dead-letters":
+		if req.Method != "GET" {
+			res.Header().Set("Allow", "GET")
+			res.WriteHeader(405)
+			return
+		}
+		writeJson(res, 200, map[string]any{
+			"deliveries": deliveryListToMaps(s.webhooks.GetDeadLetters(subscription.GetId())),
+		})
+	case len(names) == 3 && names[1] == "dead-letters":
+		s.handleAdminWebhookDeadLetter(res, req, subscription, names[2])
+	default:
+		res.WriteHeader(404)
+	}
+}
+
+func (s *ResourceManagerServer) handleAdminWebhookSubscription(res http.ResponseWriter, req *http.Request, subscription webhook.Subscription) {
+	switch req.Method {
+	case ("GET"):
+		writeJson(res, 200, subscription.ToMap())
+	case ("DELETE"):
+		s.webhooks.DeleteSubscription(subscription.GetId())
+		res.WriteHeader(204)
+	default:
+		res.Header().Set("Allow", "GET, DELETE")
+		res.WriteHeader(405)
+	}
+}
+
+func (s *ResourceManagerServer) handleAdminWebhookDeadLetter(res http.ResponseWriter, req *http.Request, subscription webhook.Subscription, deliveryId string) {
+	// 다른 구독의 전송은 찾지 않음
+	isFound := false
+	for _, delivery := range s.webhooks.GetDeadLetters(subscription.GetId()) {
+		if delivery.GetId() == deliveryId {
+			isFound = true
+			break
+		}
+	}
+	if !isFound {
+		res.WriteHeader(404)
+		return
+	}
+
+	switch req.Method {
+	case ("POST"):
+		delivery, err := s.webhooks.Redeliver(deliveryId)
+		if err != nil {
+			res.WriteHeader(getErrorStatusCode(err))
+			return
+		}
+		writeJson(res, 202, delivery.ToMap())
+	case ("DELETE"):
+		if !s.webhooks.RemoveDeadLetter(deliveryId) {
+			res.WriteHeader(404)
+			return
+		}
+		res.WriteHeader(204)
+	default:
+		res.Header().Set("Allow", "POST, DELETE")
+		res.WriteHeader(405)
+	}
+}
+
+func deliveryListToMaps(deliveries []webhook.Delivery) []map[string]any {
+	deliveryMaps := []map[string]any{}
+	for _, delivery := range deliveries {
+		deliveryMaps = append(deliveryMaps, delivery.ToMap())
+	}
+	return deliveryMaps
+}
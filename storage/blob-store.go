@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+)
+
+const (
+	// MemoryBlobStore
+	BACKEND_MEMORY = "memory"
+	// FileBlobStore
+	BACKEND_FILE = "file"
+)
+
+var (
+	// 해시에 해당하는 내용이 없는 경우
+	ErrBlobNotFound = errors.New("내용이 존재하지 않습니다")
+)
+
+/*
+파일 내용 저장소
+내용은 SHA-256 해시를 키로 하여 저장되므로 같은 내용은 한 번만 저장됨
+*/
+type BlobStore interface {
+	/*
+		내용 저장
+		  - @return {string} 내용의 해시
+		  - @return {int64} 내용의 크기(byte)
+	*/
+	Put(reader io.Reader) (string, int64, error)
+	/*
+		해시에 해당하는 내용 반환
+		내용이 없으면 ErrBlobNotFound
+	*/
+	Open(hash string) (io.ReadSeekCloser, error)
+	/*
+		해시에 해당하는 내용 삭제
+	*/
+	Delete(hash string) error
+	/*
+		저장된 모든 내용의 해시 반환
+	*/
+	List() ([]string, error)
+}
+
+/*
+저장소 종류 반환
+  - @return {string} BACKEND_MEMORY, BACKEND_FILE, 알 수 없는 저장소이면 빈 문자열
+*/
+func GetBackend(store BlobStore) string {
+	switch store.(type) {
+	case *MemoryBlobStore:
+		return BACKEND_MEMORY
+	case *FileBlobStore:
+		return BACKEND_FILE
+	default:
+		return ""
+	}
+}
+
+// 빈 내용의 해시
+var EmptyBlobHash = hashBytes([]byte{})
+
+/*
+내용의 SHA-256 해시 반환
+*/
+func hashBytes(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+/*
+SHA-256 해시의 16진수 문자열인지 여부 반환
+FileBlobStore는 해시를 파일 경로로 사용하므로 다른 문자열은 허용하지 않음
+*/
+func IsValidHash(hash string) bool {
+	if len(hash) != sha256.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(hash)
+	return err == nil
+}
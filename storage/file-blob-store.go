@@ -0,0 +1,121 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+/*
+폴더에 내용을 파일로 저장하는 저장소
+내용은 `{폴더}/{해시 앞 2글자}/{해시}` 파일로 저장되며 서버를 재시작해도 유지됨
+*/
+type FileBlobStore struct {
+	dir string
+}
+
+func (b *FileBlobStore) Put(reader io.Reader) (string, int64, error) {
+	// 해시를 모르므로 임시 파일에 쓰면서 해시 계산
+	file, err := os.CreateTemp(b.dir, ".blob-*.tmp")
+	if err != nil {
+		return "", 0, err
+	}
+	tempPath := file.Name()
+	defer os.Remove(tempPath)
+
+	hasher := sha256.New()
+	size, err := io.Copy(io.MultiWriter(file, hasher), reader)
+	if err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", 0, err
+	}
+
+	hash := hex.EncodeToString(hasher.Sum(nil))
+	if _, err := os.Stat(b.getPath(hash)); err == nil {
+		return hash, size, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(b.getPath(hash)), 0755); err != nil {
+		return "", 0, err
+	}
+	if err := os.Rename(tempPath, b.getPath(hash)); err != nil {
+		return "", 0, err
+	}
+	return hash, size, nil
+}
+
+func (b *FileBlobStore) Open(hash string) (io.ReadSeekCloser, error) {
+	if hash == EmptyBlobHash {
+		return nopCloser{bytes.NewReader([]byte{})}, nil
+	}
+	if !IsValidHash(hash) {
+		return nil, ErrBlobNotFound
+	}
+
+	file, err := os.Open(b.getPath(hash))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrBlobNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+func (b *FileBlobStore) Delete(hash string) error {
+	if !IsValidHash(hash) {
+		return nil
+	}
+	err := os.Remove(b.getPath(hash))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func (b *FileBlobStore) List() ([]string, error) {
+	hashes := []string{}
+	err := filepath.WalkDir(b.dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() && IsValidHash(entry.Name()) {
+			hashes = append(hashes, entry.Name())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(hashes)
+	return hashes, nil
+}
+
+/*
+해시에 해당하는 파일 경로 반환
+*/
+func (b *FileBlobStore) getPath(hash string) string {
+	return filepath.Join(b.dir, hash[:2], hash)
+}
+
+/*
+FileBlobStore 생성자 함수
+폴더가 없으면 생성
+*/
+func NewFileBlobStore(dir string) (*FileBlobStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &FileBlobStore{dir: dir}, nil
+}
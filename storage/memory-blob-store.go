@@ -0,0 +1,83 @@
+package storage
+
+import (
+	"bytes"
+	"io"
+	"sort"
+	"sync"
+)
+
+/*
+메모리에 내용을 저장하는 저장소
+서버를 재시작하면 내용이 사라짐
+*/
+type MemoryBlobStore struct {
+	mutex   sync.RWMutex
+	blobMap map[string]([]byte)
+}
+
+func (b *MemoryBlobStore) Put(reader io.Reader) (string, int64, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return "", 0, err
+	}
+
+	hash := hashBytes(data)
+	b.mutex.Lock()
+	b.blobMap[hash] = data
+	b.mutex.Unlock()
+	return hash, int64(len(data)), nil
+}
+
+func (b *MemoryBlobStore) Open(hash string) (io.ReadSeekCloser, error) {
+	if hash == EmptyBlobHash {
+		return nopCloser{bytes.NewReader([]byte{})}, nil
+	}
+
+	b.mutex.RLock()
+	data, ok := b.blobMap[hash]
+	b.mutex.RUnlock()
+	if !ok {
+		return nil, ErrBlobNotFound
+	}
+	return nopCloser{bytes.NewReader(data)}, nil
+}
+
+func (b *MemoryBlobStore) Delete(hash string) error {
+	b.mutex.Lock()
+	delete(b.blobMap, hash)
+	b.mutex.Unlock()
+	return nil
+}
+
+func (b *MemoryBlobStore) List() ([]string, error) {
+	b.mutex.RLock()
+	hashes := []string{}
+	for hash := range b.blobMap {
+		hashes = append(hashes, hash)
+	}
+	b.mutex.RUnlock()
+
+	sort.Strings(hashes)
+	return hashes, nil
+}
+
+/*
+Close가 아무것도 하지 않는 io.ReadSeekCloser
+*/
+type nopCloser struct {
+	io.ReadSeeker
+}
+
+func (nopCloser) Close() error {
+	return nil
+}
+
+/*
+MemoryBlobStore 생성자 함수
+*/
+func NewMemoryBlobStore() *MemoryBlobStore {
+	return &MemoryBlobStore{
+		blobMap: map[string]([]byte){},
+	}
+}
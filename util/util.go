@@ -1,9 +1,11 @@
 package util
 
 import (
+	"context"
 	"crypto/rand"
 	"errors"
 	"math/big"
+	"regexp"
 	"strings"
 )
 
@@ -32,8 +34,31 @@ func GenerateRandomString(length int) string {
 	return string(result)
 }
 
+/*
+경로가 '/'로 시작하고 빈 이름이 없는 올바른 경로인지 여부 반환
+*/
+func IsValidPath(path string) bool {
+	if path == "" || path[0] != '/' {
+		return false
+	}
+	if path == "/" {
+		return true
+	}
+
+	names := strings.Split(path, "/")
+	for i, name := range names {
+		if i == 0 {
+			continue
+		}
+		if name == "" {
+			return false
+		}
+	}
+	return true
+}
+
 func GetParentDirectory(path string) (string, error) {
-	if path[0] == '/' {
+	if path == "" || path[0] != '/' {
 		return "", errors.New("경로는 '/'으로 시작해야합니다")
 	}
 	if path == "/" {
@@ -52,6 +77,86 @@ func GetParentDirectory(path string) (string, error) {
 		}
 		parentPath += "/" + name
 	}
+	if parentPath == "" {
+		parentPath = "/"
+	}
 
 	return parentPath, nil
 }
+
+// glob 패턴의 괄호가 닫히지 않은 경우
+var ErrInvalidGlob = errors.New("올바르지 않은 glob 패턴입니다")
+
+/*
+glob 패턴을 정규식으로 변환
+`*`는 '/'를 제외한 문자열, `**`는 '/'를 포함한 문자열, `?`는 '/'를 제외한 문자 하나, `[abc]`는 괄호 안의 문자 하나와 일치
+`**` 뒤에 '/'가 오면 0개 이상의 폴더와 일치하므로 `/foo/**` 뒤에 `/*.txt`가 오는 패턴은 `/foo/a.txt`, `/foo/bar/a.txt`와 모두 일치
+여러 값과 비교할 때는 한 번만 변환하여 사용
+패턴이 올바르지 않으면 ErrInvalidGlob
+*/
+func CompileGlob(pattern string) (*regexp.Regexp, error) {
+	var expression strings.Builder
+	expression.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '*':
+			if i+2 < len(pattern) && pattern[i+1] == '*' && pattern[i+2] == '/' {
+				expression.WriteString("(?:.*/)?")
+				i += 2
+			} else if i+1 < len(pattern) && pattern[i+1] == '*' {
+				expression.WriteString(".*")
+				i++
+			} else {
+				expression.WriteString("[^/]*")
+			}
+		case '?':
+			expression.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(pattern[i:], ']')
+			if end < 0 {
+				return nil, ErrInvalidGlob
+			}
+			expression.WriteString(pattern[i : i+end+1])
+			i += end
+		default:
+			expression.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+	expression.WriteString("$")
+
+	compiled, err := regexp.Compile(expression.String())
+	if err != nil {
+		return nil, ErrInvalidGlob
+	}
+	return compiled, nil
+}
+
+/*
+glob 패턴과 일치하는지 여부 반환
+패턴 형식은 CompileGlob 참고, 패턴이 올바르지 않으면 false
+*/
+func MatchGlob(pattern string, value string) bool {
+	compiled, err := CompileGlob(pattern)
+	return err == nil && compiled.MatchString(value)
+}
+
+// context에 요청 ID를 저장하는 키
+type requestIdKey struct{}
+
+/*
+요청 ID를 저장한 context 반환
+*/
+func WithRequestId(ctx context.Context, requestId string) context.Context {
+	return context.WithValue(ctx, requestIdKey{}, requestId)
+}
+
+/*
+context에 저장된 요청 ID 반환, 없으면 빈 문자열
+*/
+func GetRequestId(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	requestId, _ := ctx.Value(requestIdKey{}).(string)
+	return requestId
+}
@@ -0,0 +1,125 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+const (
+	// 전송 중이거나 다시 시도를 기다리는 중
+	DELIVERY_STATE_PENDING = "pending"
+	// 수신자가 2xx로 응답
+	DELIVERY_STATE_SUCCEEDED = "succeeded"
+	// 모든 시도가 실패하여 실패 목록으로 옮김
+	DELIVERY_STATE_DEAD = "dead"
+)
+
+const (
+	// 본문의 HMAC-SHA256 서명, "sha256={hex}"
+	SIGNATURE_HEADER = "X-Webhook-Signature"
+	DELIVERY_HEADER  = "X-Webhook-Delivery"
+	EVENT_HEADER     = "X-Webhook-Event"
+)
+
+const signaturePrefix = "sha256="
+
+/*
+전송 시도 한 번의 결과
+*/
+type DeliveryAttempt struct {
+	AttemptedAt time.Time
+	// 수신자의 응답 코드, 응답을 받지 못했으면 0
+	StatusCode int
+	// 실패 원인, 성공하면 빈 문자열
+	Error    string
+	Duration time.Duration
+}
+
+func (a DeliveryAttempt) ToMap() map[string]any {
+	return map[string]any{
+		"attemptedAt": a.AttemptedAt.UTC().Format(time.RFC3339Nano),
+		"statusCode":  a.StatusCode,
+		"error":       a.Error,
+		"durationMs":  a.Duration.Milliseconds(),
+	}
+}
+
+/*
+이벤트 하나를 구독 하나에 전송한 기록
+*/
+type Delivery struct {
+	id             string
+	subscriptionId string
+	url            string
+	eventId        int64
+	eventType      string
+	// 전송한 본문, 다시 시도해도 같은 본문과 서명을 보냄
+	body      []byte
+	state     string
+	attempts  []DeliveryAttempt
+	createdAt time.Time
+}
+
+func (d Delivery) GetId() string {
+	return d.id
+}
+
+func (d Delivery) GetSubscriptionId() string {
+	return d.subscriptionId
+}
+
+func (d Delivery) GetEventId() int64 {
+	return d.eventId
+}
+
+func (d Delivery) GetState() string {
+	return d.state
+}
+
+func (d Delivery) GetBody() []byte {
+	return append([]byte{}, d.body...)
+}
+
+func (d Delivery) GetAttempts() []DeliveryAttempt {
+	return append([]DeliveryAttempt{}, d.attempts...)
+}
+
+func (d Delivery) ToMap() map[string]any {
+	attempts := []map[string]any{}
+	for _, attempt := range d.attempts {
+		attempts = append(attempts, attempt.ToMap())
+	}
+	return map[string]any{
+		"id":             d.id,
+		"subscriptionId": d.subscriptionId,
+		"url":            d.url,
+		"eventId":        d.eventId,
+		"eventType":      d.eventType,
+		"state":          d.state,
+		"attempts":       attempts,
+		"createdAt":      d.createdAt.UTC().Format(time.RFC3339Nano),
+	}
+}
+
+/*
+본문의 서명 헤더 값 반환
+*/
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+/*
+서명 헤더 값이 본문의 서명과 일치하는지 여부 반환
+수신자가 서명을 확인할 때 사용
+*/
+func Verify(secret string, body []byte, signature string) bool {
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		return false
+	}
+	return hmac.Equal([]byte(signature), []byte(Sign(secret, body)))
+}
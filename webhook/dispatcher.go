@@ -0,0 +1,519 @@
+package webhook
+
+import (
+	"app/class"
+	"app/util"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// 보관할 전송 기록 기본 개수
+const defaultHistorySize = 1000
+
+// 전송 한 번의 기본 제한 시간
+const defaultDeliveryTimeout = 10 * time.Second
+
+/*
+실패한 전송을 다시 시도하는 정책
+n번째 실패 후 InitialBackoff * 2^(n-1)만큼 기다리며, MaxBackoff를 넘지 않음
+*/
+type RetryPolicy struct {
+	// 첫 시도를 포함한 최대 시도 횟수
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    6,
+	InitialBackoff: time.Second,
+	MaxBackoff:     5 * time.Minute,
+}
+
+/*
+실패 횟수에 해당하는 대기 시간 반환
+*/
+func (p RetryPolicy) GetBackoff(failures int) time.Duration {
+	backoff := p.InitialBackoff
+	for i := 1; i < failures; i++ {
+		backoff *= 2
+		if p.MaxBackoff > 0 && backoff >= p.MaxBackoff {
+			return p.MaxBackoff
+		}
+	}
+	if p.MaxBackoff > 0 && backoff > p.MaxBackoff {
+		return p.MaxBackoff
+	}
+	return backoff
+}
+
+type DispatcherParam struct {
+	// 전송에 사용할 클라이언트, nil이면 제한 시간 10초의 클라이언트
+	Client *http.Client
+	// MaxAttempts가 0 이하이면 DefaultRetryPolicy
+	RetryPolicy RetryPolicy
+	// 보관할 전송 기록 개수, 0 이하이면 1000
+	HistorySize int
+	// nil이면 slog.Default()
+	Logger *slog.Logger
+}
+
+/*
+이벤트 버스의 이벤트를 구독한 URL로 전송
+전송은 이벤트마다 동시에 진행되므로 같은 구독에도 이벤트 순서대로 도착하지 않을 수 있음
+*/
+type Dispatcher struct {
+	param         DispatcherParam
+	bus           *class.EventBus
+	mutex         sync.Mutex
+	cond          *sync.Cond
+	subscriptions []Subscription
+	// 최근 전송 기록, 오래된 순서
+	history     []*Delivery
+	deadLetters []*Delivery
+	// 처리한 마지막 이벤트 번호
+	lastEventId int64
+	// 진행 중인 전송 개수
+	pendingCount int
+	isStopped    bool
+	ctx          context.Context
+	cancel       context.CancelFunc
+}
+
+/*
+이벤트 버스를 구독하여 Dispatcher 생성
+Close를 호출할 때까지 이벤트를 전송
+*/
+func NewDispatcher(bus *class.EventBus, param DispatcherParam) *Dispatcher {
+	if param.Client == nil {
+		param.Client = &http.Client{Timeout: defaultDeliveryTimeout}
+	}
+	if param.RetryPolicy.MaxAttempts <= 0 {
+		param.RetryPolicy = DefaultRetryPolicy
+	}
+	if param.HistorySize <= 0 {
+		param.HistorySize = defaultHistorySize
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	d := &Dispatcher{
+		param:         param,
+		bus:           bus,
+		subscriptions: []Subscription{},
+		history:       []*Delivery{},
+		deadLetters:   []*Delivery{},
+		ctx:           ctx,
+		cancel:        cancel,
+	}
+	d.cond = sync.NewCond(&d.mutex)
+
+	subscription, _, _ := bus.Subscribe(-1)
+	d.lastEventId = subscription.GetStartId()
+	go d.run(subscription)
+	return d
+}
+
+func (d *Dispatcher) getLogger() *slog.Logger {
+	if d.param.Logger == nil {
+		return slog.Default()
+	}
+	return d.param.Logger
+}
+
+/*
+이벤트를 받아 구독별 전송 시작
+*/
+func (d *Dispatcher) run(subscription *class.EventSubscription) {
+	defer func() {
+		subscription.Close()
+		d.mutex.Lock()
+		d.isStopped = true
+		d.cond.Broadcast()
+		d.mutex.Unlock()
+	}()
+
+	for {
+		select {
+		case <-d.ctx.Done():
+			return
+		case event, ok := <-subscription.GetChannel():
+			if ok {
+				d.dispatch(event)
+				d.setLastEventId(event.GetId())
+				continue
+			}
+
+			// 이벤트를 처리하지 못해 구독이 끊기면 보관한 이벤트로 이어서 구독
+			lastEventId := d.getLastEventId()
+			var missedEvents []class.ResourceEvent
+			var isContinuous bool
+			subscription, missedEvents, isContinuous = d.bus.Subscribe(lastEventId)
+			if !isContinuous {
+				d.getLogger().Warn("웹훅으로 전송하지 못한 이벤트가 있습니다",
+					slog.Int64("lastEventId", lastEventId),
+					slog.Int64("resumedEventId", subscription.GetStartId()))
+			}
+			for _, missedEvent := range missedEvents {
+				d.dispatch(missedEvent)
+			}
+			d.setLastEventId(subscription.GetStartId())
+		}
+	}
+}
+
+func (d *Dispatcher) getLastEventId() int64 {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.lastEventId
+}
+
+func (d *Dispatcher) setLastEventId(lastEventId int64) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.lastEventId = lastEventId
+	d.cond.Broadcast()
+}
+
+/*
+이벤트에 해당하는 구독마다 전송 기록을 만들고 전송 시작
+*/
+func (d *Dispatcher) dispatch(event class.ResourceEvent) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	for _, subscription := range d.subscriptions {
+		if !subscription.Matches(event) {
+			continue
+		}
+
+		deliveryId := util.GenerateRandomString(16)
+		body, err := json.Marshal(map[string]any{
+			"deliveryId":     deliveryId,
+			"subscriptionId": subscription.id,
+			"event":          event.ToMap(),
+		})
+		if err != nil {
+			continue
+		}
+		delivery := &Delivery{
+			id:             deliveryId,
+			subscriptionId: subscription.id,
+			url:            subscription.url,
+			eventId:        event.GetId(),
+			eventType:      event.GetType(),
+			body:           body,
+			state:          DELIVERY_STATE_PENDING,
+			attempts:       []DeliveryAttempt{},
+			createdAt:      time.Now(),
+		}
+		d.history = append(d.history, delivery)
+		if len(d.history) > d.param.HistorySize {
+			d.history = d.history[len(d.history)-d.param.HistorySize:]
+		}
+		d.startDelivery(delivery, subscription.secret)
+	}
+}
+
+/*
+전송을 별도 goroutine에서 시작
+d.mutex를 잠근 상태에서 호출
+*/
+func (d *Dispatcher) startDelivery(delivery *Delivery, secret string) {
+	d.pendingCount++
+	go func() {
+		d.deliver(delivery, secret)
+
+		d.mutex.Lock()
+		d.pendingCount--
+		d.cond.Broadcast()
+		d.mutex.Unlock()
+	}()
+}
+
+/*
+성공하거나 최대 시도 횟수가 될 때까지 전송
+모두 실패하거나 Dispatcher를 닫으면 실패 목록으로 옮김
+*/
+func (d *Dispatcher) deliver(delivery *Delivery, secret string) {
+	policy := d.param.RetryPolicy
+	failures := 0
+	for {
+		attempt := d.send(delivery, secret)
+
+		d.mutex.Lock()
+		delivery.attempts = append(delivery.attempts, attempt)
+		if attempt.Error == "" {
+			delivery.state = DELIVERY_STATE_SUCCEEDED
+			d.mutex.Unlock()
+			return
+		}
+		d.mutex.Unlock()
+
+		failures++
+		if failures >= policy.MaxAttempts || !d.sleep(policy.GetBackoff(failures)) {
+			break
+		}
+	}
+
+	d.mutex.Lock()
+	delivery.state = DELIVERY_STATE_DEAD
+	d.deadLetters = append(d.deadLetters, delivery)
+	lastAttempt := delivery.attempts[len(delivery.attempts)-1]
+	d.mutex.Unlock()
+
+	d.getLogger().Warn("웹훅 전송에 실패했습니다",
+		slog.String("deliveryId", delivery.id),
+		slog.String("subscriptionId", delivery.subscriptionId),
+		slog.String("url", delivery.url),
+		slog.Int("attempts", failures),
+		slog.String("error", lastAttempt.Error))
+}
+
+/*
+다시 시도하기 전까지 대기
+  - @return {bool} Dispatcher를 닫아 대기를 중단했으면 false
+*/
+func (d *Dispatcher) sleep(duration time.Duration) bool {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-d.ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+/*
+본문을 서명하여 한 번 전송
+수신자가 2xx로 응답하면 성공
+*/
+func (d *Dispatcher) send(delivery *Delivery, secret string) DeliveryAttempt {
+	attempt := DeliveryAttempt{AttemptedAt: time.Now()}
+
+	req, err := http.NewRequestWithContext(d.ctx, "POST", delivery.url, bytes.NewReader(delivery.body))
+	if err != nil {
+		attempt.Error = err.Error()
+		attempt.Duration = time.Since(attempt.AttemptedAt)
+		return attempt
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SIGNATURE_HEADER, Sign(secret, delivery.body))
+	req.Header.Set(DELIVERY_HEADER, delivery.id)
+	req.Header.Set(EVENT_HEADER, delivery.eventType)
+
+	res, err := d.param.Client.Do(req)
+	if err != nil {
+		attempt.Error = err.Error()
+		attempt.Duration = time.Since(attempt.AttemptedAt)
+		return attempt
+	}
+	io.Copy(io.Discard, io.LimitReader(res.Body, 64*1024))
+	res.Body.Close()
+
+	attempt.StatusCode = res.StatusCode
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		attempt.Error = "응답 코드 " + strconv.Itoa(res.StatusCode)
+	}
+	attempt.Duration = time.Since(attempt.AttemptedAt)
+	return attempt
+}
+
+/*
+구독 추가
+*/
+func (d *Dispatcher) AddSubscription(param SubscriptionParam) (Subscription, error) {
+	subscription, err := NewSubscription(param)
+	if err != nil {
+		return Subscription{}, err
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.subscriptions = append(d.subscriptions, subscription)
+	return subscription, nil
+}
+
+/*
+구독 목록을 추가한 순서로 반환
+*/
+func (d *Dispatcher) GetSubscriptions() []Subscription {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return util.CloneSlice(d.subscriptions)
+}
+
+func (d *Dispatcher) GetSubscription(id string) (Subscription, bool) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	for _, subscription := range d.subscriptions {
+		if subscription.id == id {
+			return subscription, true
+		}
+	}
+	return Subscription{}, false
+}
+
+/*
+구독 삭제
+구독의 전송 기록과 실패 목록도 삭제하며, 진행 중인 전송은 계속 진행
+  - @return {bool} 삭제 성공 여부
+*/
+func (d *Dispatcher) DeleteSubscription(id string) bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	subscriptions := []Subscription{}
+	for _, subscription := range d.subscriptions {
+		if subscription.id != id {
+			subscriptions = append(subscriptions, subscription)
+		}
+	}
+	if len(subscriptions) == len(d.subscriptions) {
+		return false
+	}
+	d.subscriptions = subscriptions
+	d.history = filterDeliveries(d.history, func(delivery *Delivery) bool {
+		return delivery.subscriptionId != id
+	})
+	d.deadLetters = filterDeliveries(d.deadLetters, func(delivery *Delivery) bool {
+		return delivery.subscriptionId != id
+	})
+	return true
+}
+
+/*
+구독의 전송 기록을 최근 순서로 반환
+  - @param {string} subscriptionId 빈 문자열이면 전체
+*/
+func (d *Dispatcher) GetDeliveries(subscriptionId string) []Delivery {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return copyDeliveries(d.history, subscriptionId)
+}
+
+/*
+모든 시도가 실패한 전송 목록을 최근 순서로 반환
+  - @param {string} subscriptionId 빈 문자열이면 전체
+*/
+func (d *Dispatcher) GetDeadLetters(subscriptionId string) []Delivery {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return copyDeliveries(d.deadLetters, subscriptionId)
+}
+
+/*
+실패 목록의 전송을 같은 본문으로 다시 전송
+최대 시도 횟수는 처음부터 다시 계산
+실패 목록에 없으면 ErrDeliveryNotFound, 구독이 삭제되었으면 ErrSubscriptionNotFound
+*/
+func (d *Dispatcher) Redeliver(deliveryId string) (Delivery, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	for i, delivery := range d.deadLetters {
+		if delivery.id != deliveryId {
+			continue
+		}
+
+		var secret string
+		isFound := false
+		for _, subscription := range d.subscriptions {
+			if subscription.id == delivery.subscriptionId {
+				secret = subscription.secret
+				isFound = true
+				break
+			}
+		}
+		if !isFound {
+			return Delivery{}, ErrSubscriptionNotFound
+		}
+
+		d.deadLetters = append(d.deadLetters[:i], d.deadLetters[i+1:]...)
+		delivery.state = DELIVERY_STATE_PENDING
+		d.startDelivery(delivery, secret)
+		return copyDelivery(delivery), nil
+	}
+	return Delivery{}, ErrDeliveryNotFound
+}
+
+/*
+실패 목록에서 전송 삭제
+  - @return {bool} 삭제 성공 여부
+*/
+func (d *Dispatcher) RemoveDeadLetter(deliveryId string) bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	count := len(d.deadLetters)
+	d.deadLetters = filterDeliveries(d.deadLetters, func(delivery *Delivery) bool {
+		return delivery.id != deliveryId
+	})
+	return len(d.deadLetters) < count
+}
+
+/*
+지금까지 발생한 이벤트의 전송이 모두 성공하거나 실패 목록으로 옮겨질 때까지 대기
+다시 시도를 기다리는 전송도 기다림
+*/
+func (d *Dispatcher) Wait() {
+	lastEventId := d.bus.GetLastId()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	for (d.lastEventId < lastEventId && !d.isStopped) || d.pendingCount > 0 {
+		d.cond.Wait()
+	}
+}
+
+/*
+이벤트 구독을 끊고 진행 중인 전송을 중단
+중단한 전송은 실패 목록으로 옮김
+*/
+func (d *Dispatcher) Close() {
+	d.cancel()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	for !d.isStopped || d.pendingCount > 0 {
+		d.cond.Wait()
+	}
+}
+
+func filterDeliveries(deliveries []*Delivery, predicate func(delivery *Delivery) bool) []*Delivery {
+	filtered := []*Delivery{}
+	for _, delivery := range deliveries {
+		if predicate(delivery) {
+			filtered = append(filtered, delivery)
+		}
+	}
+	return filtered
+}
+
+/*
+전송 기록 목록을 최근 순서로 복사
+d.mutex를 잠근 상태에서 호출
+*/
+func copyDeliveries(deliveries []*Delivery, subscriptionId string) []Delivery {
+	copied := []Delivery{}
+	for i := len(deliveries) - 1; i >= 0; i-- {
+		if subscriptionId != "" && deliveries[i].subscriptionId != subscriptionId {
+			continue
+		}
+		copied = append(copied, copyDelivery(deliveries[i]))
+	}
+	return copied
+}
+
+func copyDelivery(delivery *Delivery) Delivery {
+	copied := *delivery
+	copied.attempts = append([]DeliveryAttempt{}, delivery.attempts...)
+	return copied
+}
@@ -0,0 +1,279 @@
+package webhook
+
+import (
+	"app/class"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+/*
+받은 요청을 기록하고 정해진 응답 코드로 응답하는 테스트용 수신자
+*/
+type testReceiver struct {
+	mutex    sync.Mutex
+	requests []testRequest
+	// 요청 순서마다 응답할 코드, 요청이 더 많으면 마지막 코드
+	statusCodes []int
+}
+
+type testRequest struct {
+	header     http.Header
+	body       []byte
+	receivedAt time.Time
+}
+
+func (r *testReceiver) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+
+	r.mutex.Lock()
+	r.requests = append(r.requests, testRequest{header: req.Header.Clone(), body: body, receivedAt: time.Now()})
+	statusCode := r.statusCodes[min(len(r.requests), len(r.statusCodes))-1]
+	r.mutex.Unlock()
+
+	res.WriteHeader(statusCode)
+}
+
+func (r *testReceiver) getRequests() []testRequest {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]testRequest{}, r.requests...)
+}
+
+func (r *testReceiver) setStatusCodes(statusCodes ...int) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.statusCodes = statusCodes
+}
+
+/*
+수신자와 수신자를 구독한 Dispatcher 생성
+*/
+func newTestDispatcher(t *testing.T, policy RetryPolicy, statusCodes ...int) (*class.ResourceManager, *Dispatcher, Subscription, *testReceiver) {
+	t.Helper()
+
+	receiver := &testReceiver{statusCodes: statusCodes}
+	server := httptest.NewServer(receiver)
+	t.Cleanup(server.Close)
+
+	m := class.NewResourceManager()
+	dispatcher := NewDispatcher(m.GetEventBus(), DispatcherParam{RetryPolicy: policy})
+	t.Cleanup(dispatcher.Close)
+
+	subscription, err := dispatcher.AddSubscription(SubscriptionParam{Url: server.URL, Secret: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m, dispatcher, subscription, receiver
+}
+
+func TestRetryPolicyGetBackoff(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   RetryPolicy
+		failures int
+		want     time.Duration
+	}{
+		{name: "첫 실패", policy: RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 10 * time.Second}, failures: 1, want: time.Second},
+		{name: "두 번째 실패", policy: RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 10 * time.Second}, failures: 2, want: 2 * time.Second},
+		{name: "네 번째 실패", policy: RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 10 * time.Second}, failures: 4, want: 8 * time.Second},
+		{name: "최대 대기 시간", policy: RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 10 * time.Second}, failures: 5, want: 10 * time.Second},
+		{name: "처음부터 최대 대기 시간을 넘음", policy: RetryPolicy{InitialBackoff: time.Minute, MaxBackoff: 10 * time.Second}, failures: 1, want: 10 * time.Second},
+		{name: "최대 대기 시간 없음", policy: RetryPolicy{InitialBackoff: time.Second}, failures: 11, want: 1024 * time.Second},
+		{name: "실패 횟수가 많아도 넘치지 않음", policy: RetryPolicy{InitialBackoff: time.Second, MaxBackoff: time.Hour}, failures: 1000, want: time.Hour},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.policy.GetBackoff(test.failures); got != test.want {
+				t.Errorf("GetBackoff(%d) = %v, want %v", test.failures, got, test.want)
+			}
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"event":{}}`)
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(body)
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	if got := Sign("secret", body); got != signature {
+		t.Fatalf("Sign = %s, want %s", got, signature)
+	}
+
+	tests := []struct {
+		name      string
+		secret    string
+		body      []byte
+		signature string
+		want      bool
+	}{
+		{name: "일치", secret: "secret", body: body, signature: signature, want: true},
+		{name: "다른 비밀 값", secret: "other", body: body, signature: signature},
+		{name: "변경된 본문", secret: "secret", body: []byte(`{"event":null}`), signature: signature},
+		{name: "접두사 없음", secret: "secret", body: body, signature: signature[len("sha256="):]},
+		{name: "빈 서명", secret: "secret", body: body},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := Verify(test.secret, test.body, test.signature); got != test.want {
+				t.Errorf("Verify = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestDispatcherSignature(t *testing.T) {
+	m, dispatcher, subscription, receiver := newTestDispatcher(t, RetryPolicy{MaxAttempts: 1}, 204)
+	m.CreateResource("/a.txt", false)
+	dispatcher.Wait()
+
+	requests := receiver.getRequests()
+	if len(requests) != 1 {
+		t.Fatalf("요청 개수 = %d, want 1", len(requests))
+	}
+	request := requests[0]
+	if !Verify(subscription.GetSecret(), request.body, request.header.Get(SIGNATURE_HEADER)) {
+		t.Errorf("서명이 본문과 일치하지 않음: %s", request.header.Get(SIGNATURE_HEADER))
+	}
+	if got := request.header.Get(EVENT_HEADER); got != class.EVENT_TYPE_CREATED {
+		t.Errorf("%s = %s, want %s", EVENT_HEADER, got, class.EVENT_TYPE_CREATED)
+	}
+
+	deliveries := dispatcher.GetDeliveries(subscription.GetId())
+	if len(deliveries) != 1 || deliveries[0].GetId() != request.header.Get(DELIVERY_HEADER) {
+		t.Errorf("%s 헤더가 전송 기록의 id와 다름", DELIVERY_HEADER)
+	}
+}
+
+func TestDispatcherRetry(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: 20 * time.Millisecond, MaxBackoff: 30 * time.Millisecond}
+	tests := []struct {
+		name            string
+		statusCodes     []int
+		wantAttempts    int
+		wantState       string
+		wantDeadLetters int
+	}{
+		{name: "첫 시도에 성공", statusCodes: []int{200}, wantAttempts: 1, wantState: DELIVERY_STATE_SUCCEEDED},
+		{name: "다시 시도하여 성공", statusCodes: []int{500, 503, 200}, wantAttempts: 3, wantState: DELIVERY_STATE_SUCCEEDED},
+		{name: "2xx가 아닌 응답은 실패", statusCodes: []int{302, 404, 500}, wantAttempts: 3, wantState: DELIVERY_STATE_DEAD, wantDeadLetters: 1},
+		{name: "마지막 시도까지 실패", statusCodes: []int{500}, wantAttempts: 3, wantState: DELIVERY_STATE_DEAD, wantDeadLetters: 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m, dispatcher, subscription, receiver := newTestDispatcher(t, policy, test.statusCodes...)
+			m.CreateResource("/a.txt", false)
+			dispatcher.Wait()
+
+			requests := receiver.getRequests()
+			if len(requests) != test.wantAttempts {
+				t.Fatalf("요청 개수 = %d, want %d", len(requests), test.wantAttempts)
+			}
+			// 다시 시도할 때는 같은 본문을 보내고, 실패 횟수에 해당하는 시간만큼 기다림
+			for i := 1; i < len(requests); i++ {
+				if string(requests[i].body) != string(requests[0].body) {
+					t.Errorf("%d번째 시도의 본문이 다름", i+1)
+				}
+				if interval := requests[i].receivedAt.Sub(requests[i-1].receivedAt); interval < policy.GetBackoff(i) {
+					t.Errorf("%d번째 시도 간격 = %v, want >= %v", i+1, interval, policy.GetBackoff(i))
+				}
+			}
+
+			deliveries := dispatcher.GetDeliveries(subscription.GetId())
+			if len(deliveries) != 1 {
+				t.Fatalf("전송 기록 개수 = %d, want 1", len(deliveries))
+			}
+			if deliveries[0].GetState() != test.wantState {
+				t.Errorf("state = %s, want %s", deliveries[0].GetState(), test.wantState)
+			}
+			if len(deliveries[0].GetAttempts()) != test.wantAttempts {
+				t.Errorf("attempts = %d, want %d", len(deliveries[0].GetAttempts()), test.wantAttempts)
+			}
+			if deadLetters := dispatcher.GetDeadLetters(subscription.GetId()); len(deadLetters) != test.wantDeadLetters {
+				t.Errorf("실패 목록 개수 = %d, want %d", len(deadLetters), test.wantDeadLetters)
+			}
+		})
+	}
+}
+
+func TestDispatcherRedeliver(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond}
+	m, dispatcher, subscription, receiver := newTestDispatcher(t, policy, 500)
+	m.CreateResource("/a.txt", false)
+	dispatcher.Wait()
+
+	deadLetters := dispatcher.GetDeadLetters(subscription.GetId())
+	if len(deadLetters) != 1 {
+		t.Fatalf("실패 목록 개수 = %d, want 1", len(deadLetters))
+	}
+	deliveryId := deadLetters[0].GetId()
+
+	if _, err := dispatcher.Redeliver("unknown"); !errors.Is(err, ErrDeliveryNotFound) {
+		t.Errorf("없는 전송 error = %v, want %v", err, ErrDeliveryNotFound)
+	}
+
+	// 수신자가 복구된 후 다시 전송하면 성공하고 실패 목록에서 빠짐
+	receiver.setStatusCodes(200)
+	delivery, err := dispatcher.Redeliver(deliveryId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if delivery.GetState() != DELIVERY_STATE_PENDING {
+		t.Errorf("state = %s, want %s", delivery.GetState(), DELIVERY_STATE_PENDING)
+	}
+	dispatcher.Wait()
+
+	deliveries := dispatcher.GetDeliveries(subscription.GetId())
+	if len(deliveries) != 1 || deliveries[0].GetState() != DELIVERY_STATE_SUCCEEDED {
+		t.Fatalf("다시 전송한 기록이 성공하지 않음: %v", deliveries)
+	}
+	if len(deliveries[0].GetAttempts()) != policy.MaxAttempts+1 {
+		t.Errorf("attempts = %d, want %d", len(deliveries[0].GetAttempts()), policy.MaxAttempts+1)
+	}
+	if len(dispatcher.GetDeadLetters("")) != 0 {
+		t.Error("성공한 전송이 실패 목록에 남음")
+	}
+
+	requests := receiver.getRequests()
+	lastRequest := requests[len(requests)-1]
+	if lastRequest.header.Get(DELIVERY_HEADER) != deliveryId || string(lastRequest.body) != string(requests[0].body) {
+		t.Error("다시 전송한 요청의 id나 본문이 처음 전송과 다름")
+	}
+	if _, err := dispatcher.Redeliver(deliveryId); !errors.Is(err, ErrDeliveryNotFound) {
+		t.Errorf("성공한 전송 error = %v, want %v", err, ErrDeliveryNotFound)
+	}
+}
+
+func TestDispatcherRedeliverDeletedSubscription(t *testing.T) {
+	m, dispatcher, subscription, _ := newTestDispatcher(t, RetryPolicy{MaxAttempts: 1}, 500)
+	other, err := dispatcher.AddSubscription(SubscriptionParam{Url: "http://127.0.0.1:1", Secret: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.CreateResource("/a.txt", false)
+	dispatcher.Wait()
+
+	// 구독을 삭제하면 실패 목록에서도 빠지므로 다시 전송할 수 없음
+	deadLetters := dispatcher.GetDeadLetters(other.GetId())
+	if len(deadLetters) != 1 {
+		t.Fatalf("실패 목록 개수 = %d, want 1", len(deadLetters))
+	}
+	dispatcher.DeleteSubscription(other.GetId())
+	if _, err := dispatcher.Redeliver(deadLetters[0].GetId()); !errors.Is(err, ErrDeliveryNotFound) {
+		t.Errorf("error = %v, want %v", err, ErrDeliveryNotFound)
+	}
+	if len(dispatcher.GetDeadLetters(subscription.GetId())) != 1 {
+		t.Error("다른 구독의 실패 목록이 삭제됨")
+	}
+}
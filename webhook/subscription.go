@@ -0,0 +1,118 @@
+package webhook
+
+import (
+	"app/class"
+	"app/util"
+	"errors"
+	"net/url"
+	"slices"
+	"time"
+)
+
+var (
+	ErrInvalidUrl           = errors.New("웹훅 URL은 http 또는 https URL이어야 합니다")
+	ErrInvalidPathPrefix    = errors.New("경로는 '/'로 시작하고 빈 이름이 없어야 합니다")
+	ErrInvalidEventType     = errors.New("알 수 없는 이벤트 종류입니다")
+	ErrSubscriptionNotFound = errors.New("웹훅 구독이 없습니다")
+	ErrDeliveryNotFound     = errors.New("웹훅 전송 기록이 없습니다")
+)
+
+type SubscriptionParam struct {
+	Url string
+	// 이 경로 아래의 리소스에 대한 이벤트만 전송, 빈 문자열이면 "/"
+	PathPrefix string
+	// 전송할 이벤트 종류, 비어있으면 모든 종류
+	EventTypes []string
+	// 서명에 사용할 비밀 값, 빈 문자열이면 생성
+	Secret string
+}
+
+/*
+웹훅 구독
+*/
+type Subscription struct {
+	id         string
+	url        string
+	pathPrefix string
+	eventTypes []string
+	secret     string
+	createdAt  time.Time
+}
+
+/*
+구독 생성
+값이 올바르지 않으면 ErrInvalidUrl, ErrInvalidPathPrefix, ErrInvalidEventType
+*/
+func NewSubscription(param SubscriptionParam) (Subscription, error) {
+	parsedUrl, err := url.Parse(param.Url)
+	if err != nil || (parsedUrl.Scheme != "http" && parsedUrl.Scheme != "https") || parsedUrl.Host == "" {
+		return Subscription{}, ErrInvalidUrl
+	}
+	if param.PathPrefix == "" {
+		param.PathPrefix = "/"
+	}
+	if !util.IsValidPath(param.PathPrefix) {
+		return Subscription{}, ErrInvalidPathPrefix
+	}
+	for _, eventType := range param.EventTypes {
+		if !slices.Contains(class.KnownEventTypes, eventType) {
+			return Subscription{}, ErrInvalidEventType
+		}
+	}
+	if param.Secret == "" {
+		param.Secret = util.GenerateRandomString(32)
+	}
+
+	return Subscription{
+		id:         util.GenerateRandomString(16),
+		url:        param.Url,
+		pathPrefix: param.PathPrefix,
+		eventTypes: util.CloneSlice(param.EventTypes),
+		secret:     param.Secret,
+		createdAt:  time.Now(),
+	}, nil
+}
+
+func (s Subscription) GetId() string {
+	return s.id
+}
+
+func (s Subscription) GetUrl() string {
+	return s.url
+}
+
+func (s Subscription) GetPathPrefix() string {
+	return s.pathPrefix
+}
+
+func (s Subscription) GetEventTypes() []string {
+	return util.CloneSlice(s.eventTypes)
+}
+
+func (s Subscription) GetSecret() string {
+	return s.secret
+}
+
+/*
+이벤트가 구독한 경로, 종류에 해당하는지 여부 반환
+*/
+func (s Subscription) Matches(event class.ResourceEvent) bool {
+	if len(s.eventTypes) > 0 && !slices.Contains(s.eventTypes, event.GetType()) {
+		return false
+	}
+	return event.IsUnder(s.pathPrefix)
+}
+
+/*
+구독을 map으로 변환
+비밀 값은 포함하지 않음
+*/
+func (s Subscription) ToMap() map[string]any {
+	return map[string]any{
+		"id":         s.id,
+		"url":        s.url,
+		"pathPrefix": s.pathPrefix,
+		"eventTypes": util.CloneSlice(s.eventTypes),
+		"createdAt":  s.createdAt.UTC().Format(time.RFC3339Nano),
+	}
+}